@@ -0,0 +1,27 @@
+// Command errgen generates core.Error definitions and constructors from a YAML error spec.
+//
+// It is meant to be run via go generate:
+//
+//	//go:generate go run github.com/oysterpack/oysterpack-smart-go/core/cmd/errgen -spec errors.yaml -out errors_gen.go
+//
+// See package errgen for the spec format.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core/errgen"
+	"os"
+)
+
+func main() {
+	specFile := flag.String("spec", "errors.yaml", "error spec file")
+	outputFile := flag.String("out", "errors_gen.go", "generated Go source file")
+	flag.Parse()
+
+	if err := errgen.GenerateFile(*specFile, *outputFile, ulid.Make); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "errgen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,35 @@
+// Package coretest provides test helpers for packages that define core errors
+package coretest
+
+import (
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"testing"
+)
+
+// CheckErrorDefinitions verifies the error definitions are valid, that their IDs and names are unique, and that they
+// do not conflict with any definitions registered with the global error registry.
+func CheckErrorDefinitions(t testing.TB, definitions ...core.ErrorDefinition) {
+	t.Helper()
+	ids := make(map[ulid.ULID]core.ErrorDefinition, len(definitions))
+	names := make(map[string]core.ErrorDefinition, len(definitions))
+	for _, definition := range definitions {
+		if err := definition.Validate(); err != nil {
+			t.Error(err)
+			continue
+		}
+		if dup, ok := ids[definition.ID]; ok {
+			t.Errorf("%v and %v share the same error ID: %v", dup.Name, definition.Name, definition.ID)
+		}
+		ids[definition.ID] = definition
+		key := definition.Package + "." + definition.Name
+		if dup, ok := names[key]; ok {
+			t.Errorf("%v is defined more than once: %v, %v", definition.Name, dup.ID, definition.ID)
+		}
+		names[key] = definition
+		if registered, ok := core.LookupError(definition.ID); ok && registered != definition {
+			t.Errorf("error ID %v is already registered by a different error definition: %v.%v, %v.%v",
+				definition.ID, registered.Package, registered.Name, definition.Package, definition.Name)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package coretest
+
+import (
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"strings"
+	"testing"
+)
+
+const testPkg = "github.com/oysterpack/oysterpack-smart-go/core/coretest"
+
+// recorder records test errors without failing the test
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Error(args ...any) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCheckErrorDefinitions(t *testing.T) {
+	CheckErrorDefinitions(t,
+		core.ErrorDefinition{ID: ulid.MustParse("01HH2F3P8ZK4T0M6W2QX5B7D9R"), Name: "ErrFoo", Package: testPkg},
+		core.ErrorDefinition{ID: ulid.MustParse("01HH2F40V6N1YB8C3Q5ZR7T2KM"), Name: "ErrBar", Package: testPkg},
+	)
+
+	t.Run("invalid definitions", func(t *testing.T) {
+		id := ulid.MustParse("01HH2F3P8ZK4T0M6W2QX5B7D9R")
+		r := &recorder{TB: t}
+		CheckErrorDefinitions(r,
+			core.ErrorDefinition{ID: id, Name: "ErrFoo", Package: testPkg},
+			core.ErrorDefinition{ID: id, Name: "ErrFoo", Package: testPkg},
+			core.ErrorDefinition{Name: "ErrBar", Package: testPkg},
+			core.ErrorDefinition{ID: core.ErrDuplicateErrorID, Name: "ErrBaz", Package: testPkg},
+		)
+		t.Log(strings.Join(r.errors, "\n"))
+		if len(r.errors) != 4 {
+			t.Errorf("expected 4 errors, but was %v", len(r.errors))
+		}
+	})
+}
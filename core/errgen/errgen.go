@@ -0,0 +1,493 @@
+// Package errgen generates core.Error definitions and constructors from a declarative YAML error spec.
+//
+// Example spec:
+//
+//	package: account
+//	import_path: github.com/oysterpack/oysterpack-smart-go/crypto/algorand/account
+//	errors:
+//	  - name: ErrGetAuthAddrFailed
+//	    id: 01HGB4K37WVFBQFPM0RND212YS
+//	    description: failed to look up the account's authorized signing address
+//	    message: failed to get account auth address
+//	    cause: true
+//	    category: Unavailable
+//	    retryable: true
+//	  - name: ErrAccountAlreadyRekeyed
+//	    description: account has already been rekeyed
+//	    message: "account has already been rekeyed: {address}"
+//	    params:
+//	      - name: address
+//	        type: Address
+//	        attr: String
+//
+// For each error, the generated code declares:
+//   - an exported error ID variable named after the error
+//   - an error definition, which is registered with the core error registry on package init
+//   - an unexported constructor function, e.g., errGetAuthAddrFailed(cause error) core.Error
+//
+// Message placeholders, i.e., {param}, are replaced by the constructor params. Params are also added to the error as
+// typed attributes, which enables the param values to be retrieved without parsing the error message.
+// Errors without an ID are assigned a new ULID, which is written back to the spec. Existing IDs are never changed.
+package errgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"go/format"
+	"go/token"
+	"gopkg.in/yaml.v3"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Spec declares a package's errors
+type Spec struct {
+	Package    string      `yaml:"package"`
+	ImportPath string      `yaml:"import_path"`
+	Imports    []string    `yaml:"imports,omitempty"` // imports required by param types
+	Errors     []ErrorSpec `yaml:"errors"`
+}
+
+// ErrorSpec declares an error
+type ErrorSpec struct {
+	Name        string      `yaml:"name"`
+	ID          string      `yaml:"id,omitempty"`
+	Description string      `yaml:"description"`
+	Message     string      `yaml:"message"`
+	Params      []ParamSpec `yaml:"params,omitempty"`
+	Cause       bool        `yaml:"cause,omitempty"` // if true, then the constructor takes a cause error as its last param
+
+	// optional classification - see core.Category and core.Severity for the names
+
+	Category  string `yaml:"category,omitempty"`
+	Severity  string `yaml:"severity,omitempty"`
+	Retryable bool   `yaml:"retryable,omitempty"`
+	Permanent bool   `yaml:"permanent,omitempty"`
+}
+
+// ParamSpec declares a typed error constructor param.
+//
+// Params are added to the error as attributes - see core.Error.Attrs.
+type ParamSpec struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	// Key is the attribute key, which defaults to the param name
+	Key string `yaml:"key,omitempty"`
+	// Attr is the slog.Value kind used to store the attribute: String, Int64, Uint64, Float64, Bool or Any.
+	// The param is converted to the kind's type, e.g., Address -> string(address) for String.
+	// By default, the kind is inferred from the param type, falling back to Any.
+	// If set to "-", then the param is not added as an attribute.
+	Attr string `yaml:"attr,omitempty"`
+}
+
+var placeholder = regexp.MustCompile(`\{(\w+)}`)
+
+// ParseSpec parses a YAML error spec
+func ParseSpec(data []byte) (Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return Spec{}, err
+	}
+	return spec, nil
+}
+
+// Validate checks that the spec is complete and that error names and IDs are unique.
+//
+// Errors that have not yet been assigned an ID are allowed.
+func (s Spec) Validate() error {
+	var errs []error
+	if !token.IsIdentifier(s.Package) {
+		errs = append(errs, fmt.Errorf("invalid package name: %q", s.Package))
+	}
+	if s.ImportPath == "" {
+		errs = append(errs, errors.New("import_path is required"))
+	}
+	names := make(map[string]bool, len(s.Errors))
+	ids := make(map[string]string, len(s.Errors))
+	for _, e := range s.Errors {
+		if err := e.validate(); err != nil {
+			errs = append(errs, err)
+		}
+		if names[e.Name] {
+			errs = append(errs, fmt.Errorf("duplicate error name: %v", e.Name))
+		}
+		names[e.Name] = true
+		if e.ID == "" {
+			continue
+		}
+		if name, ok := ids[e.ID]; ok {
+			errs = append(errs, fmt.Errorf("%v and %v share the same error ID: %v", name, e.Name, e.ID))
+		}
+		ids[e.ID] = e.Name
+	}
+	return errors.Join(errs...)
+}
+
+func (e ErrorSpec) validate() error {
+	if !token.IsIdentifier(e.Name) || !strings.HasPrefix(e.Name, "Err") {
+		return fmt.Errorf("error name must be an identifier prefixed with 'Err': %q", e.Name)
+	}
+	if e.ID != "" {
+		if _, err := ulid.ParseStrict(e.ID); err != nil {
+			return fmt.Errorf("%v: invalid error ID: %w", e.Name, err)
+		}
+	}
+	if e.Message == "" {
+		return fmt.Errorf("%v: message is required", e.Name)
+	}
+	if e.Category != "" {
+		if _, err := core.ParseCategory(e.Category); err != nil {
+			return fmt.Errorf("%v: %w", e.Name, err)
+		}
+	}
+	if e.Severity != "" {
+		if _, err := core.ParseSeverity(e.Severity); err != nil {
+			return fmt.Errorf("%v: %w", e.Name, err)
+		}
+	}
+	if e.Retryable && e.Permanent {
+		return fmt.Errorf("%v: error cannot be both retryable and permanent", e.Name)
+	}
+	params := make(map[string]bool, len(e.Params))
+	for _, param := range e.Params {
+		if !token.IsIdentifier(param.Name) || param.Name == "cause" {
+			return fmt.Errorf("%v: invalid param name: %q", e.Name, param.Name)
+		}
+		if param.Type == "" {
+			return fmt.Errorf("%v: param type is required: %v", e.Name, param.Name)
+		}
+		if _, ok := attrKinds[param.Attr]; !ok && param.Attr != "-" {
+			return fmt.Errorf("%v: invalid param attr kind: %v: %q", e.Name, param.Name, param.Attr)
+		}
+		params[param.Name] = true
+	}
+	for _, match := range placeholder.FindAllStringSubmatch(e.Message, -1) {
+		if !params[match[1]] {
+			return fmt.Errorf("%v: message placeholder does not match any param: %v", e.Name, match[0])
+		}
+	}
+	return nil
+}
+
+// AssignIDs assigns new IDs to the errors that do not have an ID.
+//
+// It returns the names of the errors that were assigned IDs.
+func (s *Spec) AssignIDs(newULID func() ulid.ULID) []string {
+	var assigned []string
+	for i := range s.Errors {
+		if s.Errors[i].ID == "" {
+			s.Errors[i].ID = newULID().String()
+			assigned = append(assigned, s.Errors[i].Name)
+		}
+	}
+	return assigned
+}
+
+// Generate generates the Go source code for the spec.
+//
+// All errors must have been assigned an ID.
+func Generate(spec Spec, specFileName string) ([]byte, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	for _, e := range spec.Errors {
+		if e.ID == "" {
+			return nil, fmt.Errorf("%v: error ID has not been assigned", e.Name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := sourceTemplate.Execute(&buf, struct {
+		Spec
+		SpecFileName string
+	}{spec, specFileName}); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code is invalid: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// GenerateFile generates Go source code from the spec file and writes it to the output file.
+//
+// If any errors were assigned new IDs, then the spec file is updated with the new IDs.
+func GenerateFile(specFile, outputFile string, newULID func() ulid.ULID) error {
+	data, err := os.ReadFile(specFile)
+	if err != nil {
+		return err
+	}
+	spec, err := ParseSpec(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse spec: %v: %w", specFile, err)
+	}
+	if err := spec.Validate(); err != nil {
+		return fmt.Errorf("invalid spec: %v: %w", specFile, err)
+	}
+	if assigned := spec.AssignIDs(newULID); len(assigned) > 0 {
+		data, err = writeIDs(data, spec)
+		if err != nil {
+			return fmt.Errorf("failed to update spec with new error IDs: %v: %w", specFile, err)
+		}
+		if err := os.WriteFile(specFile, data, 0644); err != nil {
+			return err
+		}
+	}
+	src, err := Generate(spec, specFile)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputFile, src, 0644)
+}
+
+// writeIDs adds the error IDs to the spec YAML document, leaving the rest of the document, including comments, as is
+func writeIDs(data []byte, spec Spec) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	errorsNode := mappingValue(doc.Content[0], "errors")
+	if errorsNode == nil || errorsNode.Kind != yaml.SequenceNode || len(errorsNode.Content) != len(spec.Errors) {
+		return nil, errors.New("spec errors sequence not found")
+	}
+	for j, errorNode := range errorsNode.Content {
+		if mappingValue(errorNode, "id") != nil {
+			continue
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "id"}
+		value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: spec.Errors[j].ID}
+		// insert the ID after the name
+		i := 0
+		for ; i+1 < len(errorNode.Content); i += 2 {
+			if errorNode.Content[i].Value == "name" {
+				i += 2
+				break
+			}
+		}
+		content := make([]*yaml.Node, 0, len(errorNode.Content)+2)
+		content = append(content, errorNode.Content[:i]...)
+		content = append(content, key, value)
+		errorNode.Content = append(content, errorNode.Content[i:]...)
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// ConstructorName returns the name of the generated error constructor function, e.g., ErrFoo -> errFoo
+func (e ErrorSpec) ConstructorName() string {
+	r, size := utf8.DecodeRuneInString(e.Name)
+	return string(unicode.ToLower(r)) + e.Name[size:]
+}
+
+// ConstructorParams returns the generated error constructor function params
+func (e ErrorSpec) ConstructorParams() string {
+	params := make([]string, 0, len(e.Params)+1)
+	for _, param := range e.Params {
+		params = append(params, param.Name+" "+param.Type)
+	}
+	if e.Cause {
+		params = append(params, "cause error")
+	}
+	return strings.Join(params, ", ")
+}
+
+// ErrExpr returns the Go expression that constructs the underlying error from the message template
+func (e ErrorSpec) ErrExpr() string {
+	matches := placeholder.FindAllStringSubmatch(e.Message, -1)
+	if len(matches) == 0 {
+		return fmt.Sprintf("errors.New(%q)", e.Message)
+	}
+	args := make([]string, len(matches))
+	for i, match := range matches {
+		args[i] = match[1]
+	}
+	format := placeholder.ReplaceAllString(strings.ReplaceAll(e.Message, "%", "%%"), "%v")
+	return fmt.Sprintf("fmt.Errorf(%q, %v)", format, strings.Join(args, ", "))
+}
+
+// Attrs returns the params that are added to the error as attributes
+func (e ErrorSpec) Attrs() []ParamSpec {
+	var attrs []ParamSpec
+	for _, param := range e.Params {
+		if param.Attr != "-" {
+			attrs = append(attrs, param)
+		}
+	}
+	return attrs
+}
+
+// attrKinds maps the supported param attr kinds to their slog attr constructor and type
+var attrKinds = map[string]struct{ constructor, goType string }{
+	"":        {},
+	"String":  {"slog.String", "string"},
+	"Int64":   {"slog.Int64", "int64"},
+	"Uint64":  {"slog.Uint64", "uint64"},
+	"Float64": {"slog.Float64", "float64"},
+	"Bool":    {"slog.Bool", "bool"},
+	"Any":     {"slog.Any", ""},
+}
+
+// inferredAttrs maps param types to the slog attr constructor that stores the param as is
+var inferredAttrs = map[string]string{
+	"string":        "slog.String",
+	"int":           "slog.Int",
+	"int64":         "slog.Int64",
+	"uint64":        "slog.Uint64",
+	"float64":       "slog.Float64",
+	"bool":          "slog.Bool",
+	"time.Duration": "slog.Duration",
+	"time.Time":     "slog.Time",
+}
+
+// AttrExpr returns the Go expression that constructs the param's slog.Attr
+func (p ParamSpec) AttrExpr() string {
+	key := p.Key
+	if key == "" {
+		key = p.Name
+	}
+	if p.Attr == "" {
+		if constructor, ok := inferredAttrs[p.Type]; ok {
+			return fmt.Sprintf("%v(%q, %v)", constructor, key, p.Name)
+		}
+		return fmt.Sprintf("slog.Any(%q, %v)", key, p.Name)
+	}
+	kind := attrKinds[p.Attr]
+	if kind.goType == "" || kind.goType == p.Type {
+		return fmt.Sprintf("%v(%q, %v)", kind.constructor, key, p.Name)
+	}
+	return fmt.Sprintf("%v(%q, %v(%v))", kind.constructor, key, kind.goType, p.Name)
+}
+
+// UsesSlog reports whether the generated code requires the slog package
+func (s Spec) UsesSlog() bool {
+	for _, e := range s.Errors {
+		if len(e.Attrs()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesFmt reports whether the generated code requires the fmt package
+func (s Spec) UsesFmt() bool {
+	for _, e := range s.Errors {
+		if placeholder.MatchString(e.Message) {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesErrors reports whether the generated code requires the errors package
+func (s Spec) UsesErrors() bool {
+	for _, e := range s.Errors {
+		if !placeholder.MatchString(e.Message) {
+			return true
+		}
+	}
+	return false
+}
+
+var sourceTemplate = template.Must(template.New("errors").Parse(`// Code generated by errgen from {{.SpecFileName}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- if .UsesErrors}}
+	"errors"
+{{- end}}
+{{- if .UsesFmt}}
+	"fmt"
+{{- end}}
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+{{- if .UsesSlog}}
+	"log/slog"
+{{- end}}
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+var (
+{{- range .Errors}}
+	{{.Name}} = ulid.MustParse("{{.ID}}")
+{{- end}}
+)
+
+var errorDefinitions = []core.ErrorDefinition{
+{{- range .Errors}}
+	{
+		ID:          {{.Name}},
+		Name:        "{{.Name}}",
+		Description: {{printf "%q" .Description}},
+		Package:     "{{$.ImportPath}}",
+	{{- template "classification" .}}
+	},
+{{- end}}
+}
+
+func init() {
+	core.RegisterErrors(errorDefinitions...)
+}
+{{range .Errors}}
+func {{.ConstructorName}}({{.ConstructorParams}}) core.Error {
+	return core.Error{
+		ID:   {{.Name}},
+		Name: "{{.Name}}",
+		Err:  {{.ErrExpr}},
+	{{- if .Cause}}
+		Cause: cause,
+	{{- end}}
+	{{- template "classification" .}}
+	}
+	{{- with .Attrs}}.With(
+	{{- range .}}
+		{{.AttrExpr}},
+	{{- end}}
+	)
+	{{- end}}
+}
+{{end}}
+{{- define "classification"}}
+	{{- if .Category}}
+		Category: core.{{.Category}},
+	{{- end}}
+	{{- if .Severity}}
+		Severity: core.{{.Severity}},
+	{{- end}}
+	{{- if .Retryable}}
+		Retryable: true,
+	{{- end}}
+	{{- if .Permanent}}
+		Permanent: true,
+	{{- end}}
+{{- end}}`))
@@ -0,0 +1,248 @@
+package errgen
+
+import (
+	"github.com/oklog/ulid/v2"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSpec = `# test errors
+package: foo
+import_path: github.com/oysterpack/oysterpack-smart-go/foo
+errors:
+  - name: ErrFoo
+    id: 01HGAYQHT9S64TH85W8PXE0J02
+    description: foo failed
+    message: foo failed
+    cause: true
+    category: Unavailable
+    severity: High
+    retryable: true
+  # bar has no ID
+  - name: ErrBar
+    description: bar not found
+    message: "bar not found: {name} ({count}%)"
+    params:
+      - name: name
+        type: string
+      - name: count
+        type: int
+        key: bar_count
+      - name: address
+        type: Address
+        attr: String
+      - name: password
+        type: string
+        attr: "-"
+`
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := spec.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if spec.Package != "foo" || len(spec.Errors) != 2 {
+		t.Fatalf("spec was not parsed correctly: %v", spec)
+	}
+	bar := spec.Errors[1]
+	if bar.ConstructorName() != "errBar" {
+		t.Errorf("constructor name does not match: %v", bar.ConstructorName())
+	}
+	if bar.ConstructorParams() != "name string, count int, address Address, password string" {
+		t.Errorf("constructor params do not match: %v", bar.ConstructorParams())
+	}
+	if expr := bar.ErrExpr(); expr != `fmt.Errorf("bar not found: %v (%v%%)", name, count)` {
+		t.Errorf("err expression does not match: %v", expr)
+	}
+	if spec.Errors[0].ConstructorParams() != "cause error" {
+		t.Errorf("constructor params do not match: %v", spec.Errors[0].ConstructorParams())
+	}
+	attrs := bar.Attrs()
+	if len(attrs) != 3 {
+		t.Fatalf("password should not be an attribute: %v", attrs)
+	}
+	for i, expected := range []string{
+		`slog.String("name", name)`,
+		`slog.Int("bar_count", count)`,
+		`slog.String("address", string(address))`,
+	} {
+		if attrs[i].AttrExpr() != expected {
+			t.Errorf("attribute expression does not match: %v != %v", attrs[i].AttrExpr(), expected)
+		}
+	}
+}
+
+func TestSpec_Validate(t *testing.T) {
+	specs := map[string]string{
+		"duplicate ID": `
+package: foo
+import_path: foo
+errors:
+  - {name: ErrFoo, id: 01HGAYQHT9S64TH85W8PXE0J02, message: foo}
+  - {name: ErrBar, id: 01HGAYQHT9S64TH85W8PXE0J02, message: bar}
+`,
+		"duplicate name": `
+package: foo
+import_path: foo
+errors:
+  - {name: ErrFoo, message: foo}
+  - {name: ErrFoo, message: bar}
+`,
+		"invalid name": `
+package: foo
+import_path: foo
+errors:
+  - {name: Foo, message: foo}
+`,
+		"unknown placeholder": `
+package: foo
+import_path: foo
+errors:
+  - {name: ErrFoo, message: "foo {bar}"}
+`,
+		"invalid ID": `
+package: foo
+import_path: foo
+errors:
+  - {name: ErrFoo, id: foo, message: foo}
+`,
+		"invalid category": `
+package: foo
+import_path: foo
+errors:
+  - {name: ErrFoo, message: foo, category: Foo}
+`,
+		"invalid attr kind": `
+package: foo
+import_path: foo
+errors:
+  - {name: ErrFoo, message: foo, params: [{name: foo, type: int, attr: Foo}]}
+`,
+		"invalid severity": `
+package: foo
+import_path: foo
+errors:
+  - {name: ErrFoo, message: foo, severity: Foo}
+`,
+		"retryable and permanent": `
+package: foo
+import_path: foo
+errors:
+  - {name: ErrFoo, message: foo, retryable: true, permanent: true}
+`,
+		"missing import path": `
+package: foo
+errors:
+  - {name: ErrFoo, message: foo}
+`,
+	}
+	for name, data := range specs {
+		t.Run(name, func(t *testing.T) {
+			spec, err := ParseSpec([]byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = spec.Validate()
+			if err == nil {
+				t.Fatal("spec should be invalid")
+			}
+			t.Log(err)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Generate(spec, "errors.yaml"); err == nil {
+		t.Error("generating code for errors without an ID should fail")
+	}
+
+	spec.AssignIDs(ulid.Make)
+	src, err := Generate(spec, "errors.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(string(src))
+	if _, err := parser.ParseFile(token.NewFileSet(), "errors_gen.go", src, parser.AllErrors); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"// Code generated by errgen from errors.yaml. DO NOT EDIT.",
+		`ErrFoo = ulid.MustParse("01HGAYQHT9S64TH85W8PXE0J02")`,
+		`ErrBar = ulid.MustParse("` + spec.Errors[1].ID + `")`,
+		"func errFoo(cause error) core.Error {",
+		"func errBar(name string, count int, address Address, password string) core.Error {",
+		`slog.Int("bar_count", count),`,
+		"core.RegisterErrors(errorDefinitions...)",
+		"Category:    core.Unavailable,",
+		"Severity:    core.High,",
+		"Retryable:   true,",
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("generated code should contain: %v", expected)
+		}
+	}
+}
+
+func TestGenerateFile(t *testing.T) {
+	dir := t.TempDir()
+	specFile := filepath.Join(dir, "errors.yaml")
+	outputFile := filepath.Join(dir, "errors_gen.go")
+	if err := os.WriteFile(specFile, []byte(testSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := GenerateFile(specFile, outputFile, ulid.Make); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(specFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(string(data))
+	if !strings.Contains(string(data), "# bar has no ID") {
+		t.Error("spec comments should be preserved")
+	}
+	spec, err := ParseSpec(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Errors[0].ID != "01HGAYQHT9S64TH85W8PXE0J02" {
+		t.Errorf("existing ID should not change: %v", spec.Errors[0].ID)
+	}
+	barID := spec.Errors[1].ID
+	if barID == "" {
+		t.Fatal("new error should have been assigned an ID")
+	}
+
+	t.Run("IDs are stable", func(t *testing.T) {
+		if err := GenerateFile(specFile, outputFile, ulid.Make); err != nil {
+			t.Fatal(err)
+		}
+		updated, err := os.ReadFile(specFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(updated) != string(data) {
+			t.Error("spec should not change when all errors have IDs")
+		}
+		src, err := os.ReadFile(outputFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(src), barID) {
+			t.Error("generated code should use the assigned ID")
+		}
+	})
+}
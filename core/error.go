@@ -4,33 +4,268 @@ import (
 	"errors"
 	"fmt"
 	"github.com/oklog/ulid/v2"
+	"io"
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
 )
 
 type Error struct {
 	ID    ulid.ULID // unique error ID
 	Name  string    // human friendly name (naming convention is to prefix the name with "Err")
 	Err   error     // underlying error
-	Cause error     // error chain
+	Cause error     // error chain - multiple causes are supported via errors.Join, see WithCauses
+
+	// typed key/value attributes, e.g., address, round - see With and Attr
+	// They are immutable and stored behind a pointer to keep Error comparable.
+	attrs *[]slog.Attr
+
+	// Classification is optional - see IsRetryable
+
+	Category  Category // what went wrong
+	Severity  Severity // how urgently the error needs attention
+	Retryable bool     // whether the failed operation is worth retrying
+	Permanent bool     // whether the failed operation is not worth retrying, regardless of its category and causes
+
+	// Occurrence metadata is optional - see NewError
+
+	InstanceID ulid.ULID   // unique error occurrence ID
+	Time       time.Time   // when the error occurred
+	Stack      *StackTrace // where the error occurred - a pointer is used to keep Error comparable
+}
+
+// NewError constructs a new Error occurrence from the specified Error.
+//
+// The returned Error is stamped with a new InstanceID, the current time, and the caller's stack trace.
+// fxulid.NewULID can be used as the newULID function.
+func NewError(e Error, newULID func() ulid.ULID) Error {
+	e.InstanceID = newULID()
+	e.Time = time.Now()
+	stack := captureStackTrace(3)
+	e.Stack = &stack
+	return e
 }
 
 func (e Error) Error() string {
-	if e.Cause != nil {
-		return fmt.Sprintf("%v[%v]: %v <> %v", e.Name, e.ID, e.Err, e.Cause)
+	causes := e.Causes()
+	switch len(causes) {
+	case 0:
+		return fmt.Sprintf("%v[%v]: %v", e.Name, e.ID, e.Err)
+	case 1:
+		return fmt.Sprintf("%v[%v]: %v <> %v", e.Name, e.ID, e.Err, causes[0])
+	default:
+		messages := make([]string, len(causes))
+		for i, cause := range causes {
+			messages[i] = cause.Error()
+		}
+		return fmt.Sprintf("%v[%v]: %v <> [%v]", e.Name, e.ID, e.Err, strings.Join(messages, "; "))
+	}
+}
+
+// Format implements the fmt.Formatter interface.
+//
+// The "%+v" verb renders the error tree, one error per line, with causes indented beneath the error they caused.
+// Attributes and occurrence metadata are only printed using the "%+v" verb.
+func (e Error) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			e.writeTree(f, "", "")
+			return
+		}
+		_, _ = io.WriteString(f, e.Error())
+	case 's':
+		_, _ = io.WriteString(f, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(f, "%q", e.Error())
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(core.Error=%s)", verb, e.Error())
+	}
+}
+
+// writeTree writes the error header, followed by its occurrence metadata using the detail indent, followed by its
+// cause tree
+func (e Error) writeTree(w io.Writer, indent, detailIndent string) {
+	_, _ = fmt.Fprintf(w, "%v[%v]: %v", e.Name, e.ID, e.Err)
+	e.writeDetails(w, detailIndent)
+	for _, cause := range e.Causes() {
+		writeCauseTree(w, cause, indent+"  ")
+	}
+}
+
+func writeCauseTree(w io.Writer, err error, indent string) {
+	if isJoin(err) {
+		for _, cause := range unwrap(err) {
+			writeCauseTree(w, cause, indent)
+		}
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n%v- ", indent)
+	if e, ok := err.(Error); ok {
+		e.writeTree(w, indent, indent+"  ")
+		return
+	}
+	_, _ = io.WriteString(w, strings.ReplaceAll(err.Error(), "\n", "\n"+indent+"  "))
+	// the error message already includes the messages of the errors it wraps,
+	// thus only descend if there are Errors further down the tree
+	var e Error
+	if errors.As(err, &e) {
+		for _, cause := range unwrap(err) {
+			writeCauseTree(w, cause, indent+"  ")
+		}
+	}
+}
+
+func (e Error) writeDetails(w io.Writer, indent string) {
+	if attrs := e.Attrs(); len(attrs) > 0 {
+		pairs := make([]string, len(attrs))
+		for i, attr := range attrs {
+			pairs[i] = attr.String()
+		}
+		_, _ = fmt.Fprintf(w, "\n%vattrs: %v", indent, strings.Join(pairs, " "))
+	}
+	if e.InstanceID != (ulid.ULID{}) {
+		_, _ = fmt.Fprintf(w, "\n%vinstance: %v", indent, e.InstanceID)
+	}
+	if !e.Time.IsZero() {
+		_, _ = fmt.Fprintf(w, "\n%vtime: %v", indent, e.Time.Format(time.RFC3339Nano))
+	}
+	if e.Stack != nil && len(*e.Stack) > 0 {
+		stack := strings.ReplaceAll(e.Stack.String(), "\n", "\n"+indent)
+		_, _ = fmt.Fprintf(w, "\n%vstack:\n%v%v", indent, indent, stack)
+	}
+}
+
+// Causes returns the errors that caused this error.
+//
+// If the Cause is an aggregate of errors, e.g., created via errors.Join, then the aggregated errors are returned.
+func (e Error) Causes() []error {
+	if e.Cause == nil {
+		return nil
+	}
+	if isJoin(e.Cause) {
+		return unwrap(e.Cause)
 	}
-	return fmt.Sprintf("%v[%v]: %v", e.Name, e.ID, e.Err)
+	return []error{e.Cause}
+}
+
+// WithCauses returns a copy of the Error with its Cause set to the specified causes joined via errors.Join
+func (e Error) WithCauses(causes ...error) Error {
+	e.Cause = errors.Join(causes...)
+	return e
 }
 
-func (e Error) Unwrap() error {
-	return e.Cause
+// Unwrap returns the Error's causes, which enables errors.Is and errors.As to search the whole error tree
+func (e Error) Unwrap() []error {
+	return e.Causes()
 }
 
 // Is reports true if the err type is Error and if the ID matches.
-// Otherwise, check the error against its cause.
+//
+// The error's causes are checked by errors.Is via Unwrap.
 func (e Error) Is(err error) bool {
-	switch err := err.(type) {
-	case Error:
-		return e.ID == err.ID
+	target, ok := err.(Error)
+	return ok && e.ID == target.ID
+}
+
+// Find searches the error tree depth-first for an Error with the specified ID.
+//
+// Use errors.As to find the first Error in the tree regardless of its ID.
+func Find(err error, id ulid.ULID) (found Error, ok bool) {
+	walkErrorTree(err, func(err error) bool {
+		if e, isError := err.(Error); isError && e.ID == id {
+			found, ok = e, true
+		}
+		return !ok
+	})
+	return
+}
+
+// walkErrorTree visits the error tree depth-first in pre-order until visit returns false.
+//
+// Aggregate errors, e.g., created via errors.Join, are not visited, but the errors that they aggregate are.
+func walkErrorTree(err error, visit func(err error) bool) bool {
+	if err == nil {
+		return true
+	}
+	if !isJoin(err) && !visit(err) {
+		return false
+	}
+	for _, cause := range unwrap(err) {
+		if !walkErrorTree(cause, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+// unwrap returns the errors that err wraps
+func unwrap(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		return e.Unwrap()
+	case interface{ Unwrap() error }:
+		if cause := e.Unwrap(); cause != nil {
+			return []error{cause}
+		}
+	}
+	return nil
+}
+
+// isJoin reports whether the error is an aggregate of errors, e.g., created via errors.Join
+func isJoin(err error) bool {
+	switch err.(type) {
+	case Error, *remoteError:
+		return false
+	case interface{ Unwrap() []error }:
+		return true
 	default:
-		return errors.Is(err, e.Cause)
+		return false
+	}
+}
+
+// StackFrame is a single frame in a StackTrace
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+func (f StackFrame) String() string {
+	return fmt.Sprintf("%v\n\t%v:%v", f.Function, f.File, f.Line)
+}
+
+// StackTrace is ordered from the innermost frame, i.e., where the error occurred
+type StackTrace []StackFrame
+
+func (s StackTrace) String() string {
+	frames := make([]string, len(s))
+	for i, frame := range s {
+		frames[i] = frame.String()
+	}
+	return strings.Join(frames, "\n")
+}
+
+const maxStackDepth = 32
+
+// captureStackTrace captures the stack trace of the goroutine calling captureStackTrace, skipping the specified number
+// of frames where 0 is runtime.Callers and 1 is captureStackTrace
+func captureStackTrace(skip int) StackTrace {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	stack := make(StackTrace, 0, n)
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, StackFrame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
+		if !more {
+			break
+		}
 	}
+	return stack
 }
@@ -0,0 +1,222 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap/zapcore"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// With returns a copy of the Error with the specified attributes appended
+func (e Error) With(attrs ...slog.Attr) Error {
+	if len(attrs) == 0 {
+		return e
+	}
+	appended := append(slices.Clip(e.Attrs()), attrs...)
+	e.attrs = &appended
+	return e
+}
+
+// Attrs returns the error attributes.
+//
+// The returned slice must not be modified.
+func (e Error) Attrs() []slog.Attr {
+	if e.attrs == nil {
+		return nil
+	}
+	return *e.attrs
+}
+
+// Attr returns the value of the attribute for the specified key.
+//
+// If the attribute is specified more than once, then the last value wins.
+func (e Error) Attr(key string) (slog.Value, bool) {
+	attrs := e.Attrs()
+	for i := len(attrs) - 1; i >= 0; i-- {
+		if attrs[i].Key == key {
+			return attrs[i].Value.Resolve(), true
+		}
+	}
+	return slog.Value{}, false
+}
+
+// AttrValue searches the error tree depth-first for the first Error that has the attribute with the specified key,
+// and returns the attribute value if it is of type T.
+//
+// Attribute values are stored as slog.Values. Thus, T must match the slog.Value kind, e.g., string for slog.String,
+// uint64 for slog.Uint64, int64 for slog.Int64 and slog.Int, time.Duration for slog.Duration.
+//
+//	round, ok := core.AttrValue[uint64](err, "round")
+func AttrValue[T any](err error, key string) (value T, ok bool) {
+	walkErrorTree(err, func(err error) bool {
+		e, isError := err.(Error)
+		if !isError {
+			return true
+		}
+		attr, found := e.Attr(key)
+		if !found {
+			return true
+		}
+		value, ok = attr.Any().(T)
+		return false
+	})
+	return
+}
+
+// attrsLog is the log representation of Error attributes
+type attrsLog []slog.Attr
+
+func (a attrsLog) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, attr := range a {
+		if err := addZapAttr(enc, attr.Key, attr.Value.Resolve()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addZapAttr(enc zapcore.ObjectEncoder, key string, value slog.Value) error {
+	switch value.Kind() {
+	case slog.KindString:
+		enc.AddString(key, value.String())
+	case slog.KindInt64:
+		enc.AddInt64(key, value.Int64())
+	case slog.KindUint64:
+		enc.AddUint64(key, value.Uint64())
+	case slog.KindFloat64:
+		enc.AddFloat64(key, value.Float64())
+	case slog.KindBool:
+		enc.AddBool(key, value.Bool())
+	case slog.KindDuration:
+		enc.AddDuration(key, value.Duration())
+	case slog.KindTime:
+		enc.AddTime(key, value.Time())
+	case slog.KindGroup:
+		return enc.AddObject(key, attrsLog(value.Group()))
+	default:
+		return enc.AddReflected(key, value.Any())
+	}
+	return nil
+}
+
+// MarshalJSON encodes the attributes as a JSON object
+func (a attrsLog) MarshalJSON() ([]byte, error) {
+	obj := make(map[string]any, len(a))
+	for _, attr := range a {
+		value := attr.Value.Resolve()
+		if value.Kind() == slog.KindGroup {
+			obj[attr.Key] = attrsLog(value.Group())
+			continue
+		}
+		obj[attr.Key] = value.Any()
+	}
+	return json.Marshal(obj)
+}
+
+// attrJSON is the JSON wire format for an Error attribute.
+//
+// The slog.Value kind is included, which enables the attribute to be decoded with the same kind.
+type attrJSON struct {
+	Key   string          `json:"key"`
+	Kind  string          `json:"kind"`
+	Value json.RawMessage `json:"value"`
+}
+
+func toAttrsJSON(attrs []slog.Attr) ([]attrJSON, error) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	wire := make([]attrJSON, len(attrs))
+	for i, attr := range attrs {
+		value := attr.Value.Resolve()
+		var raw any
+		switch value.Kind() {
+		case slog.KindDuration:
+			raw = value.Duration().String()
+		case slog.KindTime:
+			raw = value.Time().Format(time.RFC3339Nano)
+		case slog.KindGroup:
+			group, err := toAttrsJSON(value.Group())
+			if err != nil {
+				return nil, err
+			}
+			raw = group
+		default:
+			raw = value.Any()
+		}
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode error attribute: %v: %w", attr.Key, err)
+		}
+		wire[i] = attrJSON{Key: attr.Key, Kind: value.Kind().String(), Value: data}
+	}
+	return wire, nil
+}
+
+func fromAttrsJSON(wire []attrJSON) ([]slog.Attr, error) {
+	if len(wire) == 0 {
+		return nil, nil
+	}
+	attrs := make([]slog.Attr, len(wire))
+	for i, attr := range wire {
+		value, err := attr.value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode error attribute: %v: %w", attr.Key, err)
+		}
+		attrs[i] = slog.Attr{Key: attr.Key, Value: value}
+	}
+	return attrs, nil
+}
+
+func (a attrJSON) value() (slog.Value, error) {
+	switch strings.ToLower(a.Kind) {
+	case "string":
+		var v string
+		err := json.Unmarshal(a.Value, &v)
+		return slog.StringValue(v), err
+	case "int64":
+		var v int64
+		err := json.Unmarshal(a.Value, &v)
+		return slog.Int64Value(v), err
+	case "uint64":
+		var v uint64
+		err := json.Unmarshal(a.Value, &v)
+		return slog.Uint64Value(v), err
+	case "float64":
+		var v float64
+		err := json.Unmarshal(a.Value, &v)
+		return slog.Float64Value(v), err
+	case "bool":
+		var v bool
+		err := json.Unmarshal(a.Value, &v)
+		return slog.BoolValue(v), err
+	case "duration":
+		var v string
+		if err := json.Unmarshal(a.Value, &v); err != nil {
+			return slog.Value{}, err
+		}
+		d, err := time.ParseDuration(v)
+		return slog.DurationValue(d), err
+	case "time":
+		var v string
+		if err := json.Unmarshal(a.Value, &v); err != nil {
+			return slog.Value{}, err
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return slog.TimeValue(t), err
+	case "group":
+		var v []attrJSON
+		if err := json.Unmarshal(a.Value, &v); err != nil {
+			return slog.Value{}, err
+		}
+		group, err := fromAttrsJSON(v)
+		return slog.GroupValue(group...), err
+	default:
+		var v any
+		err := json.Unmarshal(a.Value, &v)
+		return slog.AnyValue(v), err
+	}
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testAddress = "NM4BFCMMSQXKOKJSLQ5UPF3CKQOC3GOX3VOGO7XZTVSLVKC3VNCQZ6XOII"
+
+func newErrorWithAttrs() Error {
+	return NewFooErr().With(
+		slog.String("address", testAddress),
+		slog.Uint64("round", 1234),
+		slog.Int("count", -1),
+		slog.Bool("rekeyed", true),
+		slog.Duration("timeout", 5*time.Second),
+		slog.Group("wallet", slog.String("name", "foo")),
+	)
+}
+
+func TestError_With(t *testing.T) {
+	err := newErrorWithAttrs()
+
+	if address, ok := err.Attr("address"); !ok || address.String() != testAddress {
+		t.Errorf("address attribute does not match: %v", address)
+	}
+	if round, ok := err.Attr("round"); !ok || round.Uint64() != 1234 {
+		t.Errorf("round attribute does not match: %v", round)
+	}
+	if _, ok := err.Attr("txID"); ok {
+		t.Error("txID attribute should not exist")
+	}
+
+	t.Run("With does not modify the original error", func(t *testing.T) {
+		base := NewFooErr().With(slog.String("a", "1"))
+		foo := base.With(slog.String("b", "foo"))
+		bar := base.With(slog.String("b", "bar"))
+		if len(base.Attrs()) != 1 {
+			t.Errorf("base error attributes should not change: %v", base.Attrs())
+		}
+		if b, _ := foo.Attr("b"); b.String() != "foo" {
+			t.Errorf("attribute does not match: %v", b)
+		}
+		if b, _ := bar.Attr("b"); b.String() != "bar" {
+			t.Errorf("attribute does not match: %v", b)
+		}
+	})
+
+	t.Run("last value wins", func(t *testing.T) {
+		err := err.With(slog.Uint64("round", 1235))
+		if round, _ := err.Attr("round"); round.Uint64() != 1235 {
+			t.Errorf("round attribute does not match: %v", round)
+		}
+	})
+}
+
+func TestAttrValue(t *testing.T) {
+	err := NewBarErr().WithCauses(ErrBar, fmt.Errorf("wrapped: %w", newErrorWithAttrs()))
+
+	if address, ok := AttrValue[string](err, "address"); !ok || address != testAddress {
+		t.Errorf("address attribute does not match: %v", address)
+	}
+	if round, ok := AttrValue[uint64](err, "round"); !ok || round != 1234 {
+		t.Errorf("round attribute does not match: %v", round)
+	}
+	if timeout, ok := AttrValue[time.Duration](err, "timeout"); !ok || timeout != 5*time.Second {
+		t.Errorf("timeout attribute does not match: %v", timeout)
+	}
+	if _, ok := AttrValue[string](err, "round"); ok {
+		t.Error("attribute type does not match")
+	}
+	if _, ok := AttrValue[string](err, "txID"); ok {
+		t.Error("txID attribute should not exist")
+	}
+}
+
+func TestError_Attrs_Format(t *testing.T) {
+	err := newErrorWithAttrs()
+	if strings.Contains(err.Error(), testAddress) {
+		t.Error("Error() should not contain attributes")
+	}
+	verbose := fmt.Sprintf("%+v", err)
+	t.Log(verbose)
+	if !strings.Contains(verbose, "attrs: address="+testAddress+" round=1234") {
+		t.Error("verbose format should contain the attributes")
+	}
+}
+
+func TestError_Attrs_JSON(t *testing.T) {
+	err := NewBarErr().WithCauses(newErrorWithAttrs())
+	data, e := json.Marshal(err)
+	if e != nil {
+		t.Fatal(e)
+	}
+	t.Log(string(data))
+
+	var decoded Error
+	if e := json.Unmarshal(data, &decoded); e != nil {
+		t.Fatal(e)
+	}
+	foo, ok := Find(decoded, FooErrId)
+	if !ok {
+		t.Fatal("ErrFoo should be in the decoded error tree")
+	}
+	expected := newErrorWithAttrs()
+	if len(foo.Attrs()) != len(expected.Attrs()) {
+		t.Fatalf("decoded attributes do not match: %v", foo.Attrs())
+	}
+	for i, attr := range foo.Attrs() {
+		if !attr.Equal(expected.Attrs()[i]) {
+			t.Errorf("decoded attribute does not match: %v != %v", attr, expected.Attrs()[i])
+		}
+	}
+
+	t.Run("unsupported value", func(t *testing.T) {
+		_, e := json.Marshal(NewFooErr().With(slog.Any("ch", make(chan int))))
+		if e == nil {
+			t.Error("encoding should fail")
+		}
+	})
+}
+
+func TestError_Attrs_Log(t *testing.T) {
+	t.Run("zap", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		zap.New(core).Error("failure", zap.Object("error", NewBarErr().WithCauses(newErrorWithAttrs())))
+
+		logged := logs.All()[0].ContextMap()["error"].(map[string]any)
+		t.Log(logged)
+		attrs := logged["causes"].([]any)[0].(map[string]any)["attrs"].(map[string]any)
+		if attrs["address"] != testAddress || attrs["round"] != uint64(1234) {
+			t.Errorf("attributes were not logged: %v", attrs)
+		}
+		if attrs["wallet"].(map[string]any)["name"] != "foo" {
+			t.Errorf("group attribute was not logged: %v", attrs)
+		}
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		var buf bytes.Buffer
+		slog.New(slog.NewJSONHandler(&buf, nil)).Error("failure", "error", newErrorWithAttrs())
+		t.Log(buf.String())
+
+		var record struct {
+			Error struct {
+				Attrs struct {
+					Address string `json:"address"`
+					Round   uint64 `json:"round"`
+				} `json:"attrs"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		if record.Error.Attrs.Address != testAddress || record.Error.Attrs.Round != 1234 {
+			t.Errorf("attributes were not logged: %v", record.Error.Attrs)
+		}
+	})
+}
+
+func TestError_Attrs_Is(t *testing.T) {
+	if !errors.Is(newErrorWithAttrs(), NewFooErr()) {
+		t.Error("attributes should not affect error matching")
+	}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+)
+
+// Category classifies an error by what went wrong.
+//
+// The zero value means the category is unspecified.
+type Category int
+
+const (
+	InvalidArgument    Category = iota + 1 // request is invalid regardless of system state
+	NotFound                               // requested entity was not found
+	AlreadyExists                          // entity that was attempted to be created already exists
+	PermissionDenied                       // caller is not allowed to perform the operation
+	Unauthenticated                        // caller could not be authenticated, e.g., bad password
+	FailedPrecondition                     // system is not in a state required for the operation
+	Unavailable                            // service is unavailable, which is most likely a transient condition
+	DeadlineExceeded                       // operation did not complete in time
+	Internal                               // internal invariant was broken, i.e., a bug
+)
+
+var categoryNames = map[Category]string{
+	InvalidArgument:    "InvalidArgument",
+	NotFound:           "NotFound",
+	AlreadyExists:      "AlreadyExists",
+	PermissionDenied:   "PermissionDenied",
+	Unauthenticated:    "Unauthenticated",
+	FailedPrecondition: "FailedPrecondition",
+	Unavailable:        "Unavailable",
+	DeadlineExceeded:   "DeadlineExceeded",
+	Internal:           "Internal",
+}
+
+func (c Category) String() string {
+	if name, ok := categoryNames[c]; ok {
+		return name
+	}
+	if c == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Category(%d)", int(c))
+}
+
+// Retryable reports whether errors in this category are transient by nature
+func (c Category) Retryable() bool {
+	return c == Unavailable || c == DeadlineExceeded
+}
+
+// ParseCategory parses the category name
+func ParseCategory(name string) (Category, error) {
+	for category, categoryName := range categoryNames {
+		if categoryName == name {
+			return category, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid error category: %q", name)
+}
+
+// Severity indicates how urgently an error needs attention.
+//
+// The zero value means the severity is unspecified.
+type Severity int
+
+const (
+	Low Severity = iota + 1
+	Medium
+	High
+	Critical
+)
+
+var severityNames = map[Severity]string{
+	Low:      "Low",
+	Medium:   "Medium",
+	High:     "High",
+	Critical: "Critical",
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	if s == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// ParseSeverity parses the severity name
+func ParseSeverity(name string) (Severity, error) {
+	for severity, severityName := range severityNames {
+		if severityName == name {
+			return severity, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid error severity: %q", name)
+}
+
+// IsRetryable reports whether the operation that failed with the specified error is worth retrying.
+//
+// The error tree is searched depth-first for the first Error that is classified, i.e., that is flagged as retryable or
+// permanent, or that specifies a category. An Error is retryable if it is flagged as retryable or if its category is
+// retryable, unless it is flagged as permanent. Unclassified errors are not retryable.
+//
+// Flagging an Error as permanent preserves the category of its cause, e.g., an Unavailable service that is still
+// unavailable after retrying is exhausted - see CategoryOf.
+func IsRetryable(err error) (retryable bool) {
+	walkErrorTree(err, func(err error) bool {
+		e, ok := err.(Error)
+		if !ok || (!e.Retryable && !e.Permanent && e.Category == 0) {
+			return true
+		}
+		retryable = !e.Permanent && (e.Retryable || e.Category.Retryable())
+		return false
+	})
+	return
+}
+
+// CategoryOf returns the category of the first Error in the error tree that specifies a category
+func CategoryOf(err error) (category Category) {
+	walkErrorTree(err, func(err error) bool {
+		if e, ok := err.(Error); ok && e.Category != 0 {
+			category = e.Category
+			return false
+		}
+		return true
+	})
+	return
+}
@@ -0,0 +1,233 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"time"
+)
+
+// errorJSON is the JSON wire format for errors.
+//
+// Error fields map directly to the wire format. Any other error is encoded as a message, along with the errors it wraps
+// (if any) as its causes. This preserves any Errors that are wrapped further down the tree.
+type errorJSON struct {
+	ID         string       `json:"id,omitempty"`
+	Name       string       `json:"name,omitempty"`
+	Message    string       `json:"message"`
+	Category   string       `json:"category,omitempty"`
+	Severity   string       `json:"severity,omitempty"`
+	Retryable  bool         `json:"retryable,omitempty"`
+	Permanent  bool         `json:"permanent,omitempty"`
+	Attrs      []attrJSON   `json:"attrs,omitempty"`
+	InstanceID string       `json:"instance_id,omitempty"`
+	Time       *time.Time   `json:"time,omitempty"`
+	Stack      *StackTrace  `json:"stack,omitempty"`
+	Causes     []*errorJSON `json:"causes,omitempty"`
+}
+
+func toErrorJSON(err error) (*errorJSON, error) {
+	if err == nil {
+		return nil, nil
+	}
+	switch e := err.(type) {
+	case Error:
+		attrs, err := toAttrsJSON(e.Attrs())
+		if err != nil {
+			return nil, err
+		}
+		causes, err := toErrorsJSON(e.Causes())
+		if err != nil {
+			return nil, err
+		}
+		wire := &errorJSON{
+			ID:        e.ID.String(),
+			Name:      e.Name,
+			Message:   errorMessage(e.Err),
+			Category:  e.Category.String(),
+			Severity:  e.Severity.String(),
+			Retryable: e.Retryable,
+			Permanent: e.Permanent,
+			Attrs:     attrs,
+			Stack:     e.Stack,
+			Causes:    causes,
+		}
+		if e.InstanceID != (ulid.ULID{}) {
+			wire.InstanceID = e.InstanceID.String()
+		}
+		if !e.Time.IsZero() {
+			wire.Time = &e.Time
+		}
+		return wire, nil
+	default:
+		causes, e := toErrorsJSON(unwrap(err))
+		if e != nil {
+			return nil, e
+		}
+		return &errorJSON{
+			Message: err.Error(),
+			Causes:  causes,
+		}, nil
+	}
+}
+
+func toErrorsJSON(errs []error) ([]*errorJSON, error) {
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	wire := make([]*errorJSON, len(errs))
+	for i, err := range errs {
+		var e error
+		if wire[i], e = toErrorJSON(err); e != nil {
+			return nil, e
+		}
+	}
+	return wire, nil
+}
+
+func (e *errorJSON) toError() (error, error) {
+	if e == nil {
+		return nil, nil
+	}
+	causes := make([]error, len(e.Causes))
+	for i, cause := range e.Causes {
+		var err error
+		if causes[i], err = cause.toError(); err != nil {
+			return nil, err
+		}
+	}
+	if e.ID == "" {
+		return &remoteError{message: e.Message, causes: causes}, nil
+	}
+	id, err := ulid.ParseStrict(e.ID)
+	if err != nil {
+		return nil, err
+	}
+	decoded := Error{
+		ID:        id,
+		Name:      e.Name,
+		Retryable: e.Retryable,
+		Permanent: e.Permanent,
+		Stack:     e.Stack,
+	}
+	if e.Category != "" {
+		if decoded.Category, err = ParseCategory(e.Category); err != nil {
+			return nil, err
+		}
+	}
+	if e.Severity != "" {
+		if decoded.Severity, err = ParseSeverity(e.Severity); err != nil {
+			return nil, err
+		}
+	}
+	if e.Message != "" {
+		decoded.Err = errors.New(e.Message)
+	}
+	attrs, err := fromAttrsJSON(e.Attrs)
+	if err != nil {
+		return nil, err
+	}
+	decoded = decoded.With(attrs...)
+	switch len(causes) {
+	case 0:
+	case 1:
+		decoded.Cause = causes[0]
+	default:
+		decoded.Cause = errors.Join(causes...)
+	}
+	if e.InstanceID != "" {
+		if decoded.InstanceID, err = ulid.ParseStrict(e.InstanceID); err != nil {
+			return nil, err
+		}
+	}
+	if e.Time != nil {
+		decoded.Time = *e.Time
+	}
+	return decoded, nil
+}
+
+// MarshalJSON encodes the Error including its cause tree.
+//
+// Classification and occurrence metadata are only included when they are set.
+//
+// Example:
+//
+//	{
+//	  "id": "01HGB4K37WVFBQFPM0RND212YS",
+//	  "name": "ErrGetAuthAddrFailed",
+//	  "message": "failed to get account auth address",
+//	  "category": "Unavailable",
+//	  "retryable": true,
+//	  "attrs": [{"key": "address", "kind": "String", "value": "NM4BFCMMSQXKOKJSLQ5UPF3CKQOC3GOX3VOGO7XZTVSLVKC3VNCQZ6XOII"}],
+//	  "instance_id": "01HH3B0T2V5ZC8J4G9M1K7QXRN",
+//	  "time": "2023-12-08T10:15:30.123456789Z",
+//	  "causes": [{"message": "connection refused"}]
+//	}
+func (e Error) MarshalJSON() ([]byte, error) {
+	wire, err := toErrorJSON(e)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(wire)
+}
+
+// MarshalErrorJSON encodes any error using the same JSON format as Error.MarshalJSON.
+//
+// Errors that are not an Error are encoded with their message only, and any Errors they wrap are encoded as causes.
+// If err is nil, then JSON null is returned.
+func MarshalErrorJSON(err error) ([]byte, error) {
+	wire, e := toErrorJSON(err)
+	if e != nil {
+		return nil, e
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalErrorJSON decodes any error that was encoded via MarshalErrorJSON.
+//
+// Errors that were not an Error when encoded are decoded as opaque errors that preserve the original error message,
+// along with their causes. Thus, any Errors in the error tree can still be matched via errors.Is and errors.As.
+// If the data is JSON null, then nil is returned.
+func UnmarshalErrorJSON(data []byte) (error, error) {
+	var wire *errorJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return nil, err
+	}
+	return wire.toError()
+}
+
+// UnmarshalJSON decodes an Error that was encoded via MarshalJSON.
+//
+// Causes that were not Errors when encoded are decoded as opaque errors that preserve the original error message.
+// The decoded Error matches the original Error via errors.Is, because matching is based on the error ID.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var wire errorJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	if wire.ID == "" {
+		return errors.New("error ID is required")
+	}
+	decoded, err := wire.toError()
+	if err != nil {
+		return err
+	}
+	*e = decoded.(Error)
+	return nil
+}
+
+// remoteError is used to decode errors that were not an Error when encoded.
+//
+// It is used as a pointer to keep errors comparable.
+type remoteError struct {
+	message string
+	causes  []error
+}
+
+func (e *remoteError) Error() string {
+	return e.message
+}
+
+func (e *remoteError) Unwrap() []error {
+	return e.causes
+}
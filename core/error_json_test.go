@@ -0,0 +1,226 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestError_MarshalJSON(t *testing.T) {
+	// create error chain: FooErr -> BarErr -> BazErr -> ErrBaz
+	baz := NewBazErr()
+	baz.Cause = fmt.Errorf("baz failed: %w", ErrBaz)
+	bar := NewBarErr()
+	bar.Cause = baz
+	err := NewFooErr()
+	err.Cause = bar
+	err.Category = Unavailable
+	err.Severity = High
+	err.Retryable = true
+
+	data, e := json.Marshal(err)
+	if e != nil {
+		t.Fatal(e)
+	}
+	t.Log(string(data))
+
+	var decoded Error
+	if e := json.Unmarshal(data, &decoded); e != nil {
+		t.Fatal(e)
+	}
+	t.Log(decoded)
+
+	if decoded.Category != Unavailable || decoded.Severity != High || !decoded.Retryable {
+		t.Errorf("classification does not match: %v %v %v", decoded.Category, decoded.Severity, decoded.Retryable)
+	}
+	permanent := NewBarErr()
+	permanent.Permanent = true
+	var decodedPermanent Error
+	if data, e := json.Marshal(permanent); e != nil || json.Unmarshal(data, &decodedPermanent) != nil || !decodedPermanent.Permanent {
+		t.Errorf("permanent flag does not match: %v", e)
+	}
+	if decoded.Error() != err.Error() {
+		t.Errorf("decoded error message does not match: %v != %v", decoded, err)
+	}
+	for _, target := range []Error{NewFooErr(), NewBarErr(), NewBazErr()} {
+		if !errors.Is(decoded, target) {
+			t.Errorf("decoded error should match %v", target.Name)
+		}
+	}
+
+	var decodedBaz Error
+	if !errors.As(decoded.Cause, &decodedBaz) || !errors.As(decodedBaz.Cause, &decodedBaz) {
+		t.Fatal("decoded cause chain does not match")
+	}
+	if decodedBaz.ID != BazErrId {
+		t.Errorf("ErrBaz should be in the cause chain: %v", decodedBaz)
+	}
+	causes := decodedBaz.Cause.(interface{ Unwrap() []error }).Unwrap()
+	if len(causes) != 1 || causes[0].Error() != ErrBaz.Error() {
+		t.Errorf("leaf cause was not preserved: %v", causes)
+	}
+}
+
+func TestError_MarshalJSON_Occurrence(t *testing.T) {
+	err := NewError(NewFooErr(), ulid.Make)
+	data, e := json.Marshal(err)
+	if e != nil {
+		t.Fatal(e)
+	}
+	t.Log(string(data))
+
+	var decoded Error
+	if e := json.Unmarshal(data, &decoded); e != nil {
+		t.Fatal(e)
+	}
+	if decoded.InstanceID != err.InstanceID {
+		t.Errorf("instance ID does not match: %v != %v", decoded.InstanceID, err.InstanceID)
+	}
+	if !decoded.Time.Equal(err.Time) {
+		t.Errorf("time does not match: %v != %v", decoded.Time, err.Time)
+	}
+	if decoded.Stack.String() != err.Stack.String() {
+		t.Errorf("stack trace does not match: %v", decoded.Stack)
+	}
+
+	t.Run("occurrence metadata is omitted when not set", func(t *testing.T) {
+		data, e := json.Marshal(NewFooErr())
+		if e != nil {
+			t.Fatal(e)
+		}
+		for _, field := range []string{"instance_id", "time", "stack"} {
+			if strings.Contains(string(data), field) {
+				t.Errorf("%v should have been omitted: %v", field, string(data))
+			}
+		}
+	})
+}
+
+func TestError_MarshalJSON_MultipleCauses(t *testing.T) {
+	err := NewFooErr().WithCauses(NewBarErr(), fmt.Errorf("wrapped: %w", NewBazErr()))
+	data, e := json.Marshal(err)
+	if e != nil {
+		t.Fatal(e)
+	}
+	t.Log(string(data))
+
+	var decoded Error
+	if e := json.Unmarshal(data, &decoded); e != nil {
+		t.Fatal(e)
+	}
+	if len(decoded.Causes()) != 2 {
+		t.Fatalf("expected 2 causes, but found: %v", decoded.Causes())
+	}
+	if decoded.Error() != err.Error() {
+		t.Errorf("decoded error message does not match: %v != %v", decoded, err)
+	}
+	if !errors.Is(decoded, NewBarErr()) || !errors.Is(decoded, NewBazErr()) {
+		t.Error("decoded error should match any error in its tree")
+	}
+}
+
+func TestError_UnmarshalJSON(t *testing.T) {
+	t.Run("error ID is required", func(t *testing.T) {
+		var err Error
+		if e := json.Unmarshal([]byte(`{"message":"foo"}`), &err); e == nil {
+			t.Error("decoding should have failed because the error ID is missing")
+		}
+	})
+
+	t.Run("invalid error ID", func(t *testing.T) {
+		var err Error
+		if e := json.Unmarshal([]byte(`{"id":"foo","message":"foo"}`), &err); e == nil {
+			t.Error("decoding should have failed because the error ID is invalid")
+		}
+	})
+
+	t.Run("embedded in a response", func(t *testing.T) {
+		type response struct {
+			Err *Error `json:"error,omitempty"`
+		}
+		data, e := json.Marshal(response{Err: &Error{ID: FooErrId, Name: "ErrFoo", Err: ErrFoo}})
+		if e != nil {
+			t.Fatal(e)
+		}
+		var decoded response
+		if e := json.Unmarshal(data, &decoded); e != nil {
+			t.Fatal(e)
+		}
+		if decoded.Err == nil || !errors.Is(*decoded.Err, NewFooErr()) {
+			t.Errorf("decoded error does not match: %v", decoded.Err)
+		}
+	})
+}
+
+func TestMarshalErrorJSON(t *testing.T) {
+	data, e := MarshalErrorJSON(fmt.Errorf("wrapped: %w", NewFooErr()))
+	if e != nil {
+		t.Fatal(e)
+	}
+	t.Log(string(data))
+	var wire errorJSON
+	if e := json.Unmarshal(data, &wire); e != nil {
+		t.Fatal(e)
+	}
+	if wire.Message != "wrapped: "+NewFooErr().Error() || len(wire.Causes) != 1 || wire.Causes[0].ID != FooErrId.String() {
+		t.Errorf("wrapped error was not encoded: %s", data)
+	}
+
+	if data, e := MarshalErrorJSON(nil); e != nil || string(data) != "null" {
+		t.Errorf("nil error should be encoded as null: %s", data)
+	}
+}
+
+func TestUnmarshalErrorJSON(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewError(NewFooErr(), ulid.Make))
+	data, e := MarshalErrorJSON(err)
+	if e != nil {
+		t.Fatal(e)
+	}
+	decoded, e := UnmarshalErrorJSON(data)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if decoded.Error() != err.Error() {
+		t.Errorf("error message does not match: %v != %v", decoded, err)
+	}
+	var foo Error
+	if !errors.As(decoded, &foo) || !errors.Is(decoded, NewFooErr()) {
+		t.Fatalf("wrapped Error should be decoded: %+v", decoded)
+	}
+	if foo.InstanceID == (ulid.ULID{}) {
+		t.Error("occurrence metadata should be decoded")
+	}
+	if errs := map[error]bool{decoded: true}; !errs[decoded] {
+		t.Error("decoded errors should be usable as map keys")
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		data, e := MarshalErrorJSON(NewFooErr())
+		if e != nil {
+			t.Fatal(e)
+		}
+		decoded, e := UnmarshalErrorJSON(data)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if _, ok := decoded.(Error); !ok || !errors.Is(decoded, NewFooErr()) {
+			t.Errorf("decoded error does not match: %v", decoded)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		if decoded, e := UnmarshalErrorJSON([]byte("null")); e != nil || decoded != nil {
+			t.Errorf("null should be decoded as nil: %v : %v", decoded, e)
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		if _, e := UnmarshalErrorJSON([]byte("{")); e == nil {
+			t.Error("invalid JSON should fail to decode")
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"github.com/oklog/ulid/v2"
+	"go.uber.org/zap/zapcore"
+	"log/slog"
+)
+
+// MarshalLogObject implements the zapcore.ObjectMarshaler interface
+//
+//	log.Error("failed to rekey account", zap.Object("error", err))
+func (e Error) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("id", e.ID.String())
+	enc.AddString("name", e.Name)
+	enc.AddString("message", errorMessage(e.Err))
+	if e.Category != 0 {
+		enc.AddString("category", e.Category.String())
+	}
+	if e.Severity != 0 {
+		enc.AddString("severity", e.Severity.String())
+	}
+	if e.Retryable {
+		enc.AddBool("retryable", true)
+	}
+	if e.Permanent {
+		enc.AddBool("permanent", true)
+	}
+	if attrs := e.Attrs(); len(attrs) > 0 {
+		if err := enc.AddObject("attrs", attrsLog(attrs)); err != nil {
+			return err
+		}
+	}
+	if e.InstanceID != (ulid.ULID{}) {
+		enc.AddString("instance_id", e.InstanceID.String())
+	}
+	if !e.Time.IsZero() {
+		enc.AddTime("time", e.Time)
+	}
+	if e.Stack != nil && len(*e.Stack) > 0 {
+		enc.AddString("stack", e.Stack.String())
+	}
+	if e.Cause != nil {
+		return enc.AddArray("causes", causeChain(e.Causes()))
+	}
+	return nil
+}
+
+// LogValue implements the slog.LogValuer interface
+func (e Error) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", e.ID.String()),
+		slog.String("name", e.Name),
+		slog.String("message", errorMessage(e.Err)),
+	}
+	if e.Category != 0 {
+		attrs = append(attrs, slog.String("category", e.Category.String()))
+	}
+	if e.Severity != 0 {
+		attrs = append(attrs, slog.String("severity", e.Severity.String()))
+	}
+	if e.Retryable {
+		attrs = append(attrs, slog.Bool("retryable", true))
+	}
+	if e.Permanent {
+		attrs = append(attrs, slog.Bool("permanent", true))
+	}
+	if len(e.Attrs()) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "attrs", Value: slog.GroupValue(e.Attrs()...)})
+	}
+	if e.InstanceID != (ulid.ULID{}) {
+		attrs = append(attrs, slog.String("instance_id", e.InstanceID.String()))
+	}
+	if !e.Time.IsZero() {
+		attrs = append(attrs, slog.Time("time", e.Time))
+	}
+	if e.Stack != nil && len(*e.Stack) > 0 {
+		attrs = append(attrs, slog.String("stack", e.Stack.String()))
+	}
+	if e.Cause != nil {
+		attrs = append(attrs, slog.Any("causes", causeChain(e.Causes())))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// causeLogEntry is the log representation of an error in the cause tree.
+//
+// ID and Name are only set for Errors. Depth is the entry's depth in the cause tree, where direct causes have depth 0.
+type causeLogEntry struct {
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Message string   `json:"message"`
+	Attrs   attrsLog `json:"attrs,omitempty"`
+	Depth   int      `json:"depth"`
+}
+
+func (c causeLogEntry) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if c.ID != "" {
+		enc.AddString("id", c.ID)
+		enc.AddString("name", c.Name)
+	}
+	enc.AddString("message", c.Message)
+	if len(c.Attrs) > 0 {
+		if err := enc.AddObject("attrs", c.Attrs); err != nil {
+			return err
+		}
+	}
+	enc.AddInt("depth", c.Depth)
+	return nil
+}
+
+// causeChainLog is the log representation of a cause tree flattened depth-first in pre-order.
+// For a single chain of causes, the entries are ordered from the outermost cause to the root cause.
+type causeChainLog []causeLogEntry
+
+func (c causeChainLog) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, cause := range c {
+		if err := enc.AppendObject(cause); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func causeChain(causes []error) causeChainLog {
+	var chain causeChainLog
+	var appendCause func(err error, depth int)
+	appendCause = func(err error, depth int) {
+		if isJoin(err) {
+			for _, cause := range unwrap(err) {
+				appendCause(cause, depth)
+			}
+			return
+		}
+		switch e := err.(type) {
+		case Error:
+			chain = append(chain, causeLogEntry{
+				ID:      e.ID.String(),
+				Name:    e.Name,
+				Message: errorMessage(e.Err),
+				Attrs:   e.Attrs(),
+				Depth:   depth,
+			})
+		default:
+			chain = append(chain, causeLogEntry{Message: err.Error(), Depth: depth})
+		}
+		for _, cause := range unwrap(err) {
+			appendCause(cause, depth+1)
+		}
+	}
+	for _, cause := range causes {
+		appendCause(cause, 0)
+	}
+	return chain
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
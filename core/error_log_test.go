@@ -0,0 +1,127 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"log/slog"
+	"testing"
+)
+
+// FooErr -> BarErr -> wrapped ErrBaz
+func newErrorChain() Error {
+	bar := NewBarErr()
+	bar.Cause = fmt.Errorf("baz failed: %w", ErrBaz)
+	err := NewFooErr()
+	err.Cause = bar
+	return err
+}
+
+func TestError_MarshalLogObject(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := zap.New(core)
+	log.Error("failure", zap.Object("error", newErrorChain()))
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, but found %v", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	t.Log(fields)
+	logged, ok := fields["error"].(map[string]any)
+	if !ok {
+		t.Fatal("error should have been logged as an object")
+	}
+	if logged["id"] != FooErrId.String() || logged["name"] != "ErrFoo" || logged["message"] != ErrFoo.Error() {
+		t.Errorf("error was not logged correctly: %v", logged)
+	}
+	causes, ok := logged["causes"].([]any)
+	if !ok || len(causes) != 3 {
+		t.Fatalf("cause chain was not logged correctly: %v", logged["causes"])
+	}
+	if causes[0].(map[string]any)["id"] != BarErrId.String() {
+		t.Errorf("ErrBar should be the first cause: %v", causes[0])
+	}
+	if causes[2].(map[string]any)["message"] != ErrBaz.Error() {
+		t.Errorf("ErrBaz should be the root cause: %v", causes[2])
+	}
+}
+
+func TestError_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&buf, nil))
+	log.Error("failure", "error", newErrorChain())
+	t.Log(buf.String())
+
+	var record struct {
+		Error struct {
+			ID      string          `json:"id"`
+			Name    string          `json:"name"`
+			Message string          `json:"message"`
+			Causes  []causeLogEntry `json:"causes"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Error.ID != FooErrId.String() || record.Error.Name != "ErrFoo" || record.Error.Message != ErrFoo.Error() {
+		t.Errorf("error was not logged correctly: %v", record.Error)
+	}
+	if len(record.Error.Causes) != 3 {
+		t.Fatalf("cause chain was not logged correctly: %v", record.Error.Causes)
+	}
+	if record.Error.Causes[0].Name != "ErrBar" || record.Error.Causes[1].ID != "" {
+		t.Errorf("cause chain was not logged correctly: %v", record.Error.Causes)
+	}
+}
+
+func TestError_MarshalLogObject_MultipleCauses(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := zap.New(core)
+	bar := NewBarErr()
+	bar.Cause = NewBazErr()
+	log.Error("failure", zap.Object("error", NewFooErr().WithCauses(bar, ErrFoo)))
+
+	logged := logs.All()[0].ContextMap()["error"].(map[string]any)
+	t.Log(logged)
+	causes := logged["causes"].([]any)
+	if len(causes) != 3 {
+		t.Fatalf("expected 3 causes, but found %v", len(causes))
+	}
+	for i, expected := range []struct {
+		message string
+		depth   int
+	}{
+		{ErrBar.Error(), 0},
+		{ErrBaz.Error(), 1},
+		{ErrFoo.Error(), 0},
+	} {
+		cause := causes[i].(map[string]any)
+		if cause["message"] != expected.message || cause["depth"] != expected.depth {
+			t.Errorf("cause %v does not match: %v", i, cause)
+		}
+	}
+}
+
+func TestError_MarshalLogObject_Occurrence(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := zap.New(core)
+	err := NewError(NewFooErr(), ulid.Make)
+	log.Error("failure", zap.Object("error", err))
+
+	logged := logs.All()[0].ContextMap()["error"].(map[string]any)
+	t.Log(logged)
+	if logged["instance_id"] != err.InstanceID.String() {
+		t.Errorf("instance ID was not logged: %v", logged)
+	}
+	if _, ok := logged["time"]; !ok {
+		t.Errorf("time was not logged: %v", logged)
+	}
+	if _, ok := logged["stack"]; !ok {
+		t.Errorf("stack trace was not logged: %v", logged)
+	}
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrorDefinition describes an error type, i.e., an Error ID
+type ErrorDefinition struct {
+	ID          ulid.ULID // unique error ID
+	Name        string    // human friendly name (naming convention is to prefix the name with "Err")
+	Description string    // describes what the error means
+	Package     string    // import path of the package that defines the error
+
+	// default classification for Errors with this ID
+
+	Category  Category
+	Severity  Severity
+	Retryable bool
+	Permanent bool
+}
+
+// Validate checks that the ID, name and package are specified
+func (d ErrorDefinition) Validate() error {
+	if d.ID == (ulid.ULID{}) {
+		return fmt.Errorf("error definition ID is required: %v", d.Name)
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		return fmt.Errorf("error definition name is required: %v", d.ID)
+	}
+	if strings.TrimSpace(d.Package) == "" {
+		return fmt.Errorf("error definition package is required: %v[%v]", d.Name, d.ID)
+	}
+	return nil
+}
+
+var (
+	ErrInvalidErrorDefinition = ulid.MustParse("01HH2E5XKQ5V0T9M3C8YBW6R1D")
+	ErrDuplicateErrorID       = ulid.MustParse("01HH2E6C4D9Q8G3NZ1XK7PFA2T")
+)
+
+func errInvalidErrorDefinition(cause error) Error {
+	return Error{
+		ID:    ErrInvalidErrorDefinition,
+		Name:  "ErrInvalidErrorDefinition",
+		Err:   errors.New("invalid error definition"),
+		Cause: cause,
+	}
+}
+
+func errDuplicateErrorID(registered, dup ErrorDefinition) Error {
+	return Error{
+		ID:   ErrDuplicateErrorID,
+		Name: "ErrDuplicateErrorID",
+		Err: fmt.Errorf("error ID %v is already registered as %v (%v) and cannot be registered as %v (%v)",
+			dup.ID, registered.Name, registered.Package, dup.Name, dup.Package),
+	}
+}
+
+// ErrorRegistry is a catalog of ErrorDefinitions keyed by ID.
+//
+// It is safe for concurrent use.
+type ErrorRegistry struct {
+	lock        sync.RWMutex
+	definitions map[ulid.ULID]ErrorDefinition
+}
+
+// NewErrorRegistry constructs a new empty ErrorRegistry
+func NewErrorRegistry() *ErrorRegistry {
+	return &ErrorRegistry{
+		definitions: make(map[ulid.ULID]ErrorDefinition),
+	}
+}
+
+// Register adds the error definitions to the registry.
+//
+// Registration is all or nothing: if any definition is invalid or its ID is already registered by a different
+// definition, then nothing is registered and the errors are returned.
+// Registering the exact same definition more than once is allowed.
+func (r *ErrorRegistry) Register(definitions ...ErrorDefinition) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var errs []error
+	pending := make(map[ulid.ULID]ErrorDefinition, len(definitions))
+	for _, definition := range definitions {
+		if err := definition.Validate(); err != nil {
+			errs = append(errs, errInvalidErrorDefinition(err))
+			continue
+		}
+		if registered, ok := r.definitions[definition.ID]; ok && registered != definition {
+			errs = append(errs, errDuplicateErrorID(registered, definition))
+			continue
+		}
+		if registered, ok := pending[definition.ID]; ok && registered != definition {
+			errs = append(errs, errDuplicateErrorID(registered, definition))
+			continue
+		}
+		pending[definition.ID] = definition
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for id, definition := range pending {
+		r.definitions[id] = definition
+	}
+	return nil
+}
+
+// Lookup returns the ErrorDefinition for the specified error ID
+func (r *ErrorRegistry) Lookup(id ulid.ULID) (definition ErrorDefinition, ok bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	definition, ok = r.definitions[id]
+	return
+}
+
+// List returns all registered ErrorDefinitions sorted by ID
+func (r *ErrorRegistry) List() []ErrorDefinition {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	definitions := make([]ErrorDefinition, 0, len(r.definitions))
+	for _, definition := range r.definitions {
+		definitions = append(definitions, definition)
+	}
+	slices.SortFunc(definitions, func(a, b ErrorDefinition) int {
+		return a.ID.Compare(b.ID)
+	})
+	return definitions
+}
+
+var errorRegistry = NewErrorRegistry()
+
+// RegisterErrors registers the error definitions with the global error registry.
+//
+// It is meant to be called from package init functions - it panics if any definition fails to register, which
+// surfaces duplicate error IDs as soon as the offending package is loaded.
+func RegisterErrors(definitions ...ErrorDefinition) {
+	if err := errorRegistry.Register(definitions...); err != nil {
+		panic(err)
+	}
+}
+
+// LookupError returns the ErrorDefinition for the specified error ID from the global error registry
+func LookupError(id ulid.ULID) (ErrorDefinition, bool) {
+	return errorRegistry.Lookup(id)
+}
+
+// ListErrors returns all ErrorDefinitions registered with the global error registry sorted by ID
+func ListErrors() []ErrorDefinition {
+	return errorRegistry.List()
+}
+
+func init() {
+	const pkg = "github.com/oysterpack/oysterpack-smart-go/core"
+	RegisterErrors(
+		ErrorDefinition{
+			ID:          ErrInvalidErrorDefinition,
+			Name:        "ErrInvalidErrorDefinition",
+			Description: "error definition is missing its ID, name or package",
+			Package:     pkg,
+		},
+		ErrorDefinition{
+			ID:          ErrDuplicateErrorID,
+			Name:        "ErrDuplicateErrorID",
+			Description: "error ID is already registered by a different error definition",
+			Package:     pkg,
+		},
+	)
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+const testPkg = "github.com/oysterpack/oysterpack-smart-go/core"
+
+func TestErrorRegistry(t *testing.T) {
+	registry := NewErrorRegistry()
+	foo := ErrorDefinition{ID: FooErrId, Name: "ErrFoo", Description: "foo", Package: testPkg}
+	bar := ErrorDefinition{ID: BarErrId, Name: "ErrBar", Description: "bar", Package: testPkg}
+
+	t.Run("register", func(t *testing.T) {
+		if err := registry.Register(foo, bar); err != nil {
+			t.Fatal(err)
+		}
+		definition, ok := registry.Lookup(FooErrId)
+		if !ok {
+			t.Fatal("ErrFoo definition was not found")
+		}
+		if definition != foo {
+			t.Errorf("definition does not match: %v", definition)
+		}
+		if _, ok := registry.Lookup(BazErrId); ok {
+			t.Error("ErrBaz should not be registered")
+		}
+	})
+
+	t.Run("registering the same definition again is ok", func(t *testing.T) {
+		if err := registry.Register(foo); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("duplicate ID", func(t *testing.T) {
+		baz := ErrorDefinition{ID: FooErrId, Name: "ErrBaz", Description: "baz", Package: testPkg}
+		err := registry.Register(baz)
+		if !errors.Is(err, Error{ID: ErrDuplicateErrorID}) {
+			t.Fatal("duplicate ID should have failed to register:", err)
+		}
+		t.Log(err)
+		if definition, _ := registry.Lookup(FooErrId); definition != foo {
+			t.Error("registered definition should not have been replaced")
+		}
+	})
+
+	t.Run("duplicate ID within the same registration", func(t *testing.T) {
+		baz := ErrorDefinition{ID: BazErrId, Name: "ErrBaz", Description: "baz", Package: testPkg}
+		qux := ErrorDefinition{ID: BazErrId, Name: "ErrQux", Description: "qux", Package: testPkg}
+		err := registry.Register(baz, qux)
+		if !errors.Is(err, Error{ID: ErrDuplicateErrorID}) {
+			t.Fatal("duplicate ID should have failed to register:", err)
+		}
+		if _, ok := registry.Lookup(BazErrId); ok {
+			t.Error("registration should be all or nothing")
+		}
+	})
+
+	t.Run("invalid definition", func(t *testing.T) {
+		err := registry.Register(ErrorDefinition{Name: "ErrMissingID", Package: testPkg})
+		if !errors.Is(err, Error{ID: ErrInvalidErrorDefinition}) {
+			t.Error("definition without an ID should have failed to register:", err)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		definitions := registry.List()
+		if len(definitions) != 2 {
+			t.Fatalf("expected 2 definitions, but found %v", len(definitions))
+		}
+		if definitions[0].ID.Compare(definitions[1].ID) >= 0 {
+			t.Error("definitions should be sorted by ID")
+		}
+	})
+}
+
+func TestRegisterErrors(t *testing.T) {
+	definition, ok := LookupError(ErrDuplicateErrorID)
+	if !ok {
+		t.Fatal("core errors should be registered")
+	}
+	if definition.Name != "ErrDuplicateErrorID" {
+		t.Errorf("unexpected definition: %v", definition)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a duplicate error ID should panic")
+		}
+	}()
+	RegisterErrors(ErrorDefinition{ID: ErrDuplicateErrorID, Name: "ErrDup", Package: testPkg})
+}
+
+func TestListErrors(t *testing.T) {
+	definitions := ListErrors()
+	if len(definitions) == 0 {
+		t.Fatal("core errors should be registered")
+	}
+	for _, definition := range definitions {
+		t.Log(definition)
+	}
+}
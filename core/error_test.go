@@ -2,7 +2,11 @@ package core
 
 import (
 	"errors"
+	"fmt"
 	"github.com/oklog/ulid/v2"
+	"log/slog"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -77,3 +81,196 @@ func TestError_Error(t *testing.T) {
 		t.Error("error should match against any error in its chain")
 	}
 }
+
+func TestNewError(t *testing.T) {
+	err := NewError(NewFooErr(), ulid.Make)
+	if err.InstanceID == (ulid.ULID{}) {
+		t.Error("instance ID should be set")
+	}
+	if err.Time.IsZero() {
+		t.Error("time should be set")
+	}
+	if err.Stack == nil || len(*err.Stack) == 0 {
+		t.Fatal("stack trace should be captured")
+	}
+	if frame := (*err.Stack)[0]; !strings.HasSuffix(frame.Function, "TestNewError") {
+		t.Errorf("stack trace should start at the caller: %v", frame)
+	}
+	if !errors.Is(err, NewFooErr()) {
+		t.Error("error occurrences should match the error")
+	}
+
+	t.Run("occurrence metadata is only printed on request", func(t *testing.T) {
+		if strings.Contains(err.Error(), err.InstanceID.String()) {
+			t.Error("Error() should not contain the instance ID")
+		}
+		if fmt.Sprintf("%v", err) != err.Error() {
+			t.Error("default format should match Error()")
+		}
+		verbose := fmt.Sprintf("%+v", err)
+		t.Log(verbose)
+		if !strings.HasPrefix(verbose, err.Error()) {
+			t.Error("verbose format should start with Error()")
+		}
+		for _, expected := range []string{err.InstanceID.String(), "TestNewError", "error_test.go"} {
+			if !strings.Contains(verbose, expected) {
+				t.Errorf("%%+v should contain %q", expected)
+			}
+		}
+		if fmt.Sprintf("%+v", NewFooErr()) != NewFooErr().Error() {
+			t.Error("verbose format should match Error() when there is no occurrence metadata")
+		}
+	})
+
+	t.Run("errors are comparable", func(t *testing.T) {
+		err := err.With(slog.String("a", "1"))
+		var a, b error = err, err
+		if a != b {
+			t.Error("an error should equal itself")
+		}
+		if a == error(NewError(NewFooErr(), ulid.Make)) {
+			t.Error("error occurrences should not be equal")
+		}
+		errs := map[error]bool{a: true}
+		if !errs[b] {
+			t.Error("errors should be usable as map keys")
+		}
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	unavailable := NewFooErr()
+	unavailable.Category = Unavailable
+	notFound := NewBarErr()
+	notFound.Category = NotFound
+	retryable := NewBazErr()
+	retryable.Retryable = true
+
+	if !IsRetryable(unavailable) {
+		t.Error("Unavailable errors should be retryable")
+	}
+	if IsRetryable(notFound) {
+		t.Error("NotFound errors should not be retryable")
+	}
+	if !IsRetryable(retryable) {
+		t.Error("errors flagged as retryable should be retryable")
+	}
+	if IsRetryable(ErrFoo) || IsRetryable(NewFooErr()) {
+		t.Error("unclassified errors should not be retryable")
+	}
+
+	// the first classified error in the chain wins
+	err := NewFooErr()
+	err.Cause = fmt.Errorf("wrapped: %w", unavailable)
+	if !IsRetryable(err) {
+		t.Error("cause classification should be used when the error is not classified")
+	}
+	notFound.Cause = unavailable
+	if IsRetryable(notFound) {
+		t.Error("error classification should take precedence over its cause")
+	}
+	if CategoryOf(err) != Unavailable {
+		t.Errorf("unexpected category: %v", CategoryOf(err))
+	}
+
+	// permanent errors are not retryable, regardless of their category and causes
+	permanent := NewFooErr()
+	permanent.Permanent = true
+	permanent.Cause = unavailable
+	if IsRetryable(permanent) {
+		t.Error("errors flagged as permanent should not be retryable")
+	}
+	if CategoryOf(permanent) != Unavailable {
+		t.Errorf("permanent errors should report the category of their cause: %v", CategoryOf(permanent))
+	}
+	permanent.Category = Unavailable
+	if IsRetryable(permanent) {
+		t.Error("errors flagged as permanent should not be retryable, regardless of their category")
+	}
+}
+
+func TestParseCategory(t *testing.T) {
+	for category := InvalidArgument; category <= Internal; category++ {
+		parsed, err := ParseCategory(category.String())
+		if err != nil {
+			t.Error(err)
+		}
+		if parsed != category {
+			t.Errorf("parsed category does not match: %v != %v", parsed, category)
+		}
+	}
+	if _, err := ParseCategory("Foo"); err == nil {
+		t.Error("invalid category name should fail to parse")
+	}
+	if _, err := ParseSeverity("Critical"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestError_Causes(t *testing.T) {
+	// FooErr -> [BarErr -> wrapped BazErr, ErrBaz]
+	bar := NewBarErr()
+	bar.Cause = fmt.Errorf("wrapped: %w", NewError(NewBazErr(), ulid.Make))
+	err := NewFooErr().WithCauses(bar, ErrBaz)
+
+	causes := err.Causes()
+	if len(causes) != 2 {
+		t.Fatalf("expected 2 causes, but found %v", len(causes))
+	}
+	if len(NewFooErr().Causes()) != 0 {
+		t.Error("error without a cause should have no causes")
+	}
+
+	for _, target := range []error{NewBarErr(), NewBazErr(), ErrBaz} {
+		if !errors.Is(err, target) {
+			t.Errorf("error should match any error in its tree: %v", target)
+		}
+	}
+	if errors.Is(err, Error{ID: ulid.Make()}) {
+		t.Error("error should not match")
+	}
+
+	t.Run("errors.As", func(t *testing.T) {
+		var e Error
+		if !errors.As(err, &e) || e.ID != FooErrId {
+			t.Errorf("errors.As should find the root error first: %v", e)
+		}
+		if !errors.As(errors.Join(ErrFoo, bar), &e) || e.ID != BarErrId {
+			t.Errorf("errors.As should find Errors in joined errors: %v", e)
+		}
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		baz, ok := Find(err, BazErrId)
+		if !ok {
+			t.Fatal("ErrBaz should be found in the error tree")
+		}
+		if baz.InstanceID == (ulid.ULID{}) {
+			t.Error("found error should be the ErrBaz occurrence")
+		}
+		if _, ok := Find(err, ulid.Make()); ok {
+			t.Error("error should not be found")
+		}
+	})
+
+	t.Run("format", func(t *testing.T) {
+		t.Log(err)
+		if !strings.HasSuffix(err.Error(), "; baz error]") {
+			t.Errorf("Error() should list the causes: %v", err)
+		}
+		verbose := fmt.Sprintf("%+v", err)
+		t.Log(verbose)
+		lines := strings.Split(verbose, "\n")
+		for _, expected := range []string{
+			"ErrFoo[01HGAYQHT9S64TH85W8PXE0J02]: foo error",
+			"  - ErrBar[01HGB2DZNSMWM5J3AV40WQ6JRW]: bar error",
+			"    - wrapped: ErrBaz[01HGB3N53PS74WGR2YXVXMCCKB]: baz error",
+			"      - ErrBaz[01HGB3N53PS74WGR2YXVXMCCKB]: baz error",
+			"  - baz error",
+		} {
+			if !slices.Contains(lines, expected) {
+				t.Errorf("error tree should contain line: %q", expected)
+			}
+		}
+	})
+}
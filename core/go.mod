@@ -3,14 +3,13 @@ module github.com/oysterpack/oysterpack-smart-go/core
 go 1.21.4
 
 require (
+	github.com/benbjohnson/clock v1.3.5
 	github.com/oklog/ulid/v2 v2.1.0
-	go.uber.org/fx v1.20.1
 	go.uber.org/zap v1.23.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	go.uber.org/atomic v1.7.0 // indirect
-	go.uber.org/dig v1.17.0 // indirect
 	go.uber.org/multierr v1.6.0 // indirect
-	golang.org/x/sys v0.0.0-20220412211240-33da011f77ad // indirect
 )
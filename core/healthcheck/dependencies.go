@@ -0,0 +1,102 @@
+package healthcheck
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"slices"
+	"strings"
+	"time"
+)
+
+// findCycle returns the first dependency cycle that is found, e.g., [a, b, a], or nil if the dependency graph is a DAG.
+//
+// Dependencies that are not in the checks map are ignored.
+func findCycle(checks map[ulid.ULID]Check) []Check {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[ulid.ULID]int, len(checks))
+	var path []Check
+	var visit func(check Check) []Check
+	visit = func(check Check) []Check {
+		state[check.ID] = visiting
+		path = append(path, check)
+		for _, id := range check.DependsOn {
+			dependency, ok := checks[id]
+			if !ok {
+				continue
+			}
+			switch state[id] {
+			case visiting:
+				start := slices.IndexFunc(path, func(check Check) bool { return check.ID == id })
+				return append(slices.Clone(path[start:]), dependency)
+			case unvisited:
+				if cycle := visit(dependency); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[check.ID] = visited
+		return nil
+	}
+
+	ids := make([]ulid.ULID, 0, len(checks))
+	for id := range checks {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, ulid.ULID.Compare)
+	for _, id := range ids {
+		if state[id] == unvisited {
+			if cycle := visit(checks[id]); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+func formatCycle(cycle []Check) string {
+	names := make([]string, len(cycle))
+	for i, check := range cycle {
+		names[i] = check.Name
+	}
+	return strings.Join(names, " -> ")
+}
+
+// impacted returns the result for a check that is impacted by failed dependencies, i.e., dependencies that are Red.
+// The root causes are the failed upstream checks that were not themselves impacted.
+//
+// If no dependencies failed, then false is returned, i.e., the check should be run.
+func impacted(check Check, dependencies []CheckResult, now time.Time) (CheckResult, bool) {
+	var rootCauses []ulid.ULID
+	var errs []error
+	for _, dependency := range dependencies {
+		if dependency.Status != Red {
+			continue
+		}
+		if dependency.Impacted {
+			rootCauses = append(rootCauses, dependency.RootCauses...)
+		} else {
+			rootCauses = append(rootCauses, dependency.Check.ID)
+		}
+		if dependency.Err != nil {
+			errs = append(errs, dependency.Err)
+		}
+	}
+	if len(rootCauses) == 0 {
+		return CheckResult{}, false
+	}
+	slices.SortFunc(rootCauses, ulid.ULID.Compare)
+	rootCauses = slices.Compact(rootCauses)
+	err := errHealthCheckImpacted(check.Name, errors.Join(errs...))
+	return CheckResult{
+		Check:      check,
+		Result:     Result{Message: err.Error(), Status: Red, Err: err},
+		Time:       now,
+		Impacted:   true,
+		RootCauses: rootCauses,
+	}, true
+}
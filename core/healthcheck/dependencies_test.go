@@ -0,0 +1,182 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"github.com/benbjohnson/clock"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func dependsOn(check Check, dependencies ...Check) Check {
+	for _, dependency := range dependencies {
+		check.DependsOn = append(check.DependsOn, dependency.ID)
+	}
+	return check
+}
+
+func TestRegistry_Register_Dependencies(t *testing.T) {
+	algod := newCheck("algod", green)
+	kmd := dependsOn(newCheck("kmd", green), algod)
+
+	t.Run("dependencies can be registered in the same call", func(t *testing.T) {
+		if err := NewRegistry().Register(kmd, algod); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("dependencies can be registered before", func(t *testing.T) {
+		registry := NewRegistry()
+		if err := registry.Register(algod); err != nil {
+			t.Fatal(err)
+		}
+		if err := registry.Register(kmd); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("unknown dependency", func(t *testing.T) {
+		err := NewRegistry().Register(kmd)
+		if !errors.Is(err, core.Error{ID: ErrUnknownHealthCheckDependency}) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		a := newCheck("a", green)
+		b := dependsOn(newCheck("b", green), a)
+		c := dependsOn(newCheck("c", green), b)
+		a = dependsOn(a, c)
+		registry := NewRegistry()
+		err := registry.Register(a, b, c)
+		if !errors.Is(err, core.Error{ID: ErrHealthCheckDependencyCycle}) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		t.Log(err)
+		if len(registry.List()) != 0 {
+			t.Error("registration should be all or nothing")
+		}
+	})
+
+	t.Run("self dependency", func(t *testing.T) {
+		a := newCheck("a", green)
+		a = dependsOn(a, a)
+		if err := NewRegistry().Register(a); !errors.Is(err, core.Error{ID: ErrHealthCheckDependencyCycle}) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestFindCycle(t *testing.T) {
+	a := newCheck("a", green)
+	b := dependsOn(newCheck("b", green), a)
+	c := dependsOn(newCheck("c", green), a, b)
+	graph := map[ulid.ULID]Check{a.ID: a, b.ID: b, c.ID: c}
+	if cycle := findCycle(graph); cycle != nil {
+		t.Errorf("graph is a DAG: %v", formatCycle(cycle))
+	}
+
+	graph[a.ID] = dependsOn(a, c)
+	cycle := findCycle(graph)
+	if len(cycle) < 3 || cycle[0].ID != cycle[len(cycle)-1].ID {
+		t.Errorf("cycle was not found: %v", formatCycle(cycle))
+	}
+	t.Log(formatCycle(cycle))
+}
+
+func TestRunner_Run_Dependencies(t *testing.T) {
+	var kmdRuns atomic.Int32
+	algod := newCheck("algod", func(time.Duration) Result {
+		time.Sleep(10 * time.Millisecond)
+		return Result{Status: Red, Err: errors.New("connection refused")}
+	})
+	indexer := newCheck("indexer", status(Yellow))
+	kmd := dependsOn(newCheck("kmd", func(time.Duration) Result {
+		kmdRuns.Add(1)
+		return Result{Status: Green}
+	}), algod, indexer)
+	account := dependsOn(newCheck("account", green), kmd)
+	unrelated := newCheck("unrelated", green)
+
+	report := Runner{}.Run(context.Background(), account, kmd, unrelated, algod, indexer)
+	results := make(map[string]CheckResult)
+	for _, result := range report.Results {
+		results[result.Check.Name] = result
+	}
+
+	if kmdRuns.Load() != 0 {
+		t.Error("kmd check should not have been run")
+	}
+	for _, name := range []string{"kmd", "account"} {
+		result := results[name]
+		if !result.Impacted || result.Status != Red || !slices.Equal(result.RootCauses, []ulid.ULID{algod.ID}) {
+			t.Errorf("%v should be impacted by algod: %+v", name, result)
+		}
+		if !errors.Is(result.Err, core.Error{ID: ErrHealthCheckImpacted}) {
+			t.Errorf("%v: unexpected error: %v", name, result.Err)
+		}
+	}
+	for _, name := range []string{"algod", "indexer", "unrelated"} {
+		if results[name].Impacted {
+			t.Errorf("%v should not be impacted", name)
+		}
+	}
+
+	t.Run("dependencies that are not being run are ignored", func(t *testing.T) {
+		report := Runner{}.Run(context.Background(), kmd)
+		if report.Results[0].Impacted || report.Status != Green {
+			t.Errorf("unexpected result: %+v", report.Results[0])
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		a := newCheck("a", green)
+		b := dependsOn(newCheck("b", green), a)
+		a = dependsOn(a, b)
+		report := Runner{}.Run(context.Background(), a, b)
+		if report.Status != Green {
+			t.Errorf("dependencies should be ignored: %+v", report)
+		}
+	})
+}
+
+func TestScheduler_Dependencies(t *testing.T) {
+	mock := clock.NewMock()
+	var algodStatus atomic.Int32
+	algodStatus.Store(int32(Green))
+	algod := newCheck("algod", func(time.Duration) Result {
+		return Result{Status: Status(algodStatus.Load())}
+	})
+	algod.Interval = time.Second
+	kmd := dependsOn(newCheck("kmd", green), algod)
+	kmd.Interval = 2 * time.Second
+
+	registry := NewRegistry()
+	if err := registry.Register(algod, kmd); err != nil {
+		t.Fatal(err)
+	}
+	scheduler := NewScheduler(registry, SchedulerConfig{Clock: mock})
+	scheduler.Start(context.Background())
+	defer scheduler.Stop()
+
+	// algod runs on its own first, then kmd is run using algod's latest result
+	algodStatus.Store(int32(Red))
+	mock.Add(algod.Interval)
+	waitForRuns(t, scheduler, algod, 2)
+	mock.Add(algod.Interval)
+	waitForRuns(t, scheduler, kmd, 2)
+	result, _ := scheduler.Result(kmd.ID)
+	if !result.Impacted {
+		t.Fatalf("kmd should be impacted: %+v", result)
+	}
+	if !slices.Equal(result.RootCauses, []ulid.ULID{algod.ID}) {
+		t.Errorf("root cause does not match: %v", result.RootCauses)
+	}
+	if !result.Time.Equal(mock.Now()) {
+		t.Errorf("result time should be set using the scheduler clock: %v", result.Time)
+	}
+}
@@ -0,0 +1,3 @@
+package healthcheck
+
+//go:generate go run github.com/oysterpack/oysterpack-smart-go/core/cmd/errgen -spec errors.yaml -out errors_gen.go
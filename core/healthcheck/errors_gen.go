@@ -0,0 +1,175 @@
+// Code generated by errgen from errors.yaml. DO NOT EDIT.
+
+package healthcheck
+
+import (
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrInvalidHealthCheck           = ulid.MustParse("01M5401CX2WZSQYBT9X79BVQZP")
+	ErrDuplicateHealthCheckID       = ulid.MustParse("01M5401CX2WZSQYBT9XA70BCZC")
+	ErrHealthCheckTimeout           = ulid.MustParse("01M5401CX2WZSQYBT9XD78MZCJ")
+	ErrHealthCheckPanic             = ulid.MustParse("01M5401CX2WZSQYBT9XDCET3RM")
+	ErrHealthChecksNotGreen         = ulid.MustParse("01M540APXM3JKY838JKHG2XH4B")
+	ErrUnknownHealthCheckDependency = ulid.MustParse("01M540GBJK5Q1TH5ZZV07BBG5E")
+	ErrHealthCheckDependencyCycle   = ulid.MustParse("01M540GBJK5Q1TH5ZZV194A9TS")
+	ErrHealthCheckImpacted          = ulid.MustParse("01M540GBJK5Q1TH5ZZV2V1J05J")
+)
+
+var errorDefinitions = []core.ErrorDefinition{
+	{
+		ID:          ErrInvalidHealthCheck,
+		Name:        "ErrInvalidHealthCheck",
+		Description: "health check is missing its ID, name or func, its timeout or interval is negative, or its category is invalid",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/core/healthcheck",
+		Category:    core.InvalidArgument,
+	},
+	{
+		ID:          ErrDuplicateHealthCheckID,
+		Name:        "ErrDuplicateHealthCheckID",
+		Description: "health check ID is already registered",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/core/healthcheck",
+		Category:    core.AlreadyExists,
+	},
+	{
+		ID:          ErrHealthCheckTimeout,
+		Name:        "ErrHealthCheckTimeout",
+		Description: "health check did not complete within its timeout",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/core/healthcheck",
+		Category:    core.DeadlineExceeded,
+	},
+	{
+		ID:          ErrHealthCheckPanic,
+		Name:        "ErrHealthCheckPanic",
+		Description: "health check panicked",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/core/healthcheck",
+		Category:    core.Internal,
+	},
+	{
+		ID:          ErrHealthChecksNotGreen,
+		Name:        "ErrHealthChecksNotGreen",
+		Description: "health checks did not become Green before the deadline",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/core/healthcheck",
+		Category:    core.Unavailable,
+	},
+	{
+		ID:          ErrUnknownHealthCheckDependency,
+		Name:        "ErrUnknownHealthCheckDependency",
+		Description: "health check depends on a check that is not registered",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/core/healthcheck",
+		Category:    core.InvalidArgument,
+	},
+	{
+		ID:          ErrHealthCheckDependencyCycle,
+		Name:        "ErrHealthCheckDependencyCycle",
+		Description: "health check dependencies form a cycle",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/core/healthcheck",
+		Category:    core.InvalidArgument,
+	},
+	{
+		ID:          ErrHealthCheckImpacted,
+		Name:        "ErrHealthCheckImpacted",
+		Description: "health check was not run because one or more of its dependencies failed - the dependency errors are the cause",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/core/healthcheck",
+		Category:    core.Unavailable,
+	},
+}
+
+func init() {
+	core.RegisterErrors(errorDefinitions...)
+}
+
+func errInvalidHealthCheck(cause error) core.Error {
+	return core.Error{
+		ID:       ErrInvalidHealthCheck,
+		Name:     "ErrInvalidHealthCheck",
+		Err:      errors.New("invalid health check"),
+		Cause:    cause,
+		Category: core.InvalidArgument,
+	}
+}
+
+func errDuplicateHealthCheckID(id ulid.ULID) core.Error {
+	return core.Error{
+		ID:       ErrDuplicateHealthCheckID,
+		Name:     "ErrDuplicateHealthCheckID",
+		Err:      fmt.Errorf("health check ID is already registered: %v", id),
+		Category: core.AlreadyExists,
+	}.With(
+		slog.Any("id", id),
+	)
+}
+
+func errHealthCheckTimeout(check string, timeout time.Duration) core.Error {
+	return core.Error{
+		ID:       ErrHealthCheckTimeout,
+		Name:     "ErrHealthCheckTimeout",
+		Err:      fmt.Errorf("health check timed out after %v: %v", timeout, check),
+		Category: core.DeadlineExceeded,
+	}.With(
+		slog.String("check", check),
+		slog.Duration("timeout", timeout),
+	)
+}
+
+func errHealthCheckPanic(check string, recovered any) core.Error {
+	return core.Error{
+		ID:       ErrHealthCheckPanic,
+		Name:     "ErrHealthCheckPanic",
+		Err:      fmt.Errorf("health check panicked: %v: %v", check, recovered),
+		Category: core.Internal,
+	}.With(
+		slog.String("check", check),
+	)
+}
+
+func errHealthChecksNotGreen(status Status, cause error) core.Error {
+	return core.Error{
+		ID:       ErrHealthChecksNotGreen,
+		Name:     "ErrHealthChecksNotGreen",
+		Err:      fmt.Errorf("health checks are not Green: %v", status),
+		Cause:    cause,
+		Category: core.Unavailable,
+	}
+}
+
+func errUnknownHealthCheckDependency(check string, dependency ulid.ULID) core.Error {
+	return core.Error{
+		ID:       ErrUnknownHealthCheckDependency,
+		Name:     "ErrUnknownHealthCheckDependency",
+		Err:      fmt.Errorf("health check depends on a check that is not registered: %v -> %v", check, dependency),
+		Category: core.InvalidArgument,
+	}.With(
+		slog.String("check", check),
+		slog.Any("dependency", dependency),
+	)
+}
+
+func errHealthCheckDependencyCycle(cycle string) core.Error {
+	return core.Error{
+		ID:       ErrHealthCheckDependencyCycle,
+		Name:     "ErrHealthCheckDependencyCycle",
+		Err:      fmt.Errorf("health check dependency cycle: %v", cycle),
+		Category: core.InvalidArgument,
+	}.With(
+		slog.String("cycle", cycle),
+	)
+}
+
+func errHealthCheckImpacted(check string, cause error) core.Error {
+	return core.Error{
+		ID:       ErrHealthCheckImpacted,
+		Name:     "ErrHealthCheckImpacted",
+		Err:      fmt.Errorf("health check is impacted by failed dependencies: %v", check),
+		Cause:    cause,
+		Category: core.Unavailable,
+	}.With(
+		slog.String("check", check),
+	)
+}
@@ -1,7 +1,15 @@
+// Package healthcheck provides a registry of health checks and a runner that runs them concurrently, aggregating the
+// results into an overall health status.
 package healthcheck
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
+// HealthCheck checks the health of some part of the system.
+//
+// The timeout is the time budget the health check has to complete - see Runner.
 type HealthCheck func(timeout time.Duration) Result
 
 type Result struct {
@@ -18,3 +26,66 @@ const (
 	Yellow                   // Healthy but requires attention
 	Red                      // Not healthy
 )
+
+var statusNames = map[Status]string{
+	Green:  "Green",
+	Yellow: "Yellow",
+	Red:    "Red",
+}
+
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// ParseStatus parses the status name
+func ParseStatus(name string) (Status, error) {
+	for status, statusName := range statusNames {
+		if statusName == name {
+			return status, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid health check status: %q", name)
+}
+
+func (s Status) valid() bool {
+	return s >= Green && s <= Red
+}
+
+// Category defines what a health check is for, which determines how orchestrators react to the check's status
+type Category int
+
+const (
+	Liveness  Category = iota + 1 // if not healthy, then the process should be restarted
+	Readiness                     // if not healthy, then the process should not receive traffic
+	Startup                       // if not healthy, then the process is still initializing
+)
+
+var categoryNames = map[Category]string{
+	Liveness:  "Liveness",
+	Readiness: "Readiness",
+	Startup:   "Startup",
+}
+
+func (c Category) String() string {
+	if name, ok := categoryNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Category(%d)", int(c))
+}
+
+// ParseCategory parses the category name
+func ParseCategory(name string) (Category, error) {
+	for category, categoryName := range categoryNames {
+		if categoryName == name {
+			return category, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid health check category: %q", name)
+}
+
+func (c Category) valid() bool {
+	return c >= Liveness && c <= Startup
+}
@@ -0,0 +1,190 @@
+// Package healthhttp exposes health check reports over HTTP as JSON.
+//
+// The standard health endpoints report on the checks in the corresponding category:
+//   - /healthz - all checks
+//   - /livez - Liveness checks
+//   - /readyz - Readiness checks
+//   - /startupz - Startup checks
+//
+// The aggregate health status is mapped to the HTTP status code:
+//   - Green, Yellow -> 200 OK
+//   - Red -> 503 Service Unavailable
+//
+// Checks can be filtered via query params, which are added to the handler's base selector:
+//   - tag - selects checks that have at least one of the tags, e.g., ?tag=algod&tag=kmd
+//   - check - selects checks by name, e.g., ?check=algod
+package healthhttp
+
+import (
+	"encoding/json"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"github.com/oysterpack/oysterpack-smart-go/core/healthcheck"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// standard health endpoint paths
+const (
+	HealthzPath  = "/healthz"
+	LivezPath    = "/livez"
+	ReadyzPath   = "/readyz"
+	StartupzPath = "/startupz"
+)
+
+// StatusCode maps the health status to the HTTP status code
+func StatusCode(status healthcheck.Status) int {
+	if status == healthcheck.Green || status == healthcheck.Yellow {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
+
+// NewHandler returns an HTTP handler that reports on the checks selected by the base selector and the request's
+// query params.
+func NewHandler(reporter healthcheck.Reporter, selector healthcheck.Selector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		query := req.URL.Query()
+		selector := healthcheck.Selector{
+			Tags:       append(slices.Clip(selector.Tags), query["tag"]...),
+			Names:      append(slices.Clip(selector.Names), query["check"]...),
+			Categories: selector.Categories,
+		}
+		report := reporter.Report(req.Context(), selector)
+		data, err := json.Marshal(toReportJSON(report))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(StatusCode(report.Status))
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	})
+}
+
+// NewServeMux returns a ServeMux that serves the standard health endpoints: /healthz, /livez, /readyz and /startupz
+func NewServeMux(reporter healthcheck.Reporter) *http.ServeMux {
+	category := func(category healthcheck.Category) http.Handler {
+		return NewHandler(reporter, healthcheck.Selector{Categories: []healthcheck.Category{category}})
+	}
+	mux := http.NewServeMux()
+	mux.Handle(HealthzPath, NewHandler(reporter, healthcheck.Selector{}))
+	mux.Handle(LivezPath, category(healthcheck.Liveness))
+	mux.Handle(ReadyzPath, category(healthcheck.Readiness))
+	mux.Handle(StartupzPath, category(healthcheck.Startup))
+	return mux
+}
+
+// reportJSON is the JSON representation of a health check report
+type reportJSON struct {
+	Status     string            `json:"status"`
+	Categories map[string]string `json:"categories,omitempty"`
+	Time       time.Time         `json:"time"`
+	Duration   string            `json:"duration"`
+	Checks     []checkResultJSON `json:"checks"`
+}
+
+type checkResultJSON struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Categories  []string        `json:"categories,omitempty"`
+	Critical    bool            `json:"critical,omitempty"`
+	Status      string          `json:"status"`
+	Message     string          `json:"message,omitempty"`
+	Time        time.Time       `json:"time"`
+	Duration    string          `json:"duration"`
+	Err         json.RawMessage `json:"error,omitempty"` // can be decoded via core.UnmarshalErrorJSON
+	Impacted    bool            `json:"impacted,omitempty"`
+	RootCauses  []rootCauseJSON `json:"root_causes,omitempty"`
+}
+
+// rootCauseJSON identifies a failed upstream check that a check is impacted by
+type rootCauseJSON struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func toReportJSON(report healthcheck.Report) reportJSON {
+	results := make(map[ulid.ULID]healthcheck.CheckResult, len(report.Results))
+	for _, result := range report.Results {
+		results[result.Check.ID] = result
+	}
+	rootCauses := func(ids []ulid.ULID) []rootCauseJSON {
+		if len(ids) == 0 {
+			return nil
+		}
+		rootCauses := make([]rootCauseJSON, len(ids))
+		for i, id := range ids {
+			// root causes that were not selected for the report are identified by ID only
+			result := results[id]
+			rootCauses[i] = rootCauseJSON{ID: id.String(), Name: result.Check.Name, Message: result.Message}
+		}
+		return rootCauses
+	}
+
+	checks := make([]checkResultJSON, len(report.Results))
+	for i, result := range report.Results {
+		checks[i] = checkResultJSON{
+			ID:          result.Check.ID.String(),
+			Name:        result.Check.Name,
+			Description: result.Check.Description,
+			Tags:        result.Check.Tags,
+			Categories:  categoryNames(result.Check.Categories),
+			Critical:    result.Check.Critical,
+			Status:      result.Status.String(),
+			Message:     result.Message,
+			Time:        result.Time,
+			Duration:    result.Duration.String(),
+			Err:         errorJSON(result.Err),
+			Impacted:    result.Impacted,
+			RootCauses:  rootCauses(result.RootCauses),
+		}
+	}
+	categories := make(map[string]string, len(report.Categories))
+	for category, status := range report.Categories {
+		categories[category.String()] = status.String()
+	}
+	return reportJSON{
+		Status:     report.Status.String(),
+		Categories: categories,
+		Time:       report.Time,
+		Duration:   report.Duration.String(),
+		Checks:     checks,
+	}
+}
+
+func categoryNames(categories []healthcheck.Category) []string {
+	if len(categories) == 0 {
+		return nil
+	}
+	names := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.String()
+	}
+	return names
+}
+
+// errorJSON encodes the error, falling back to its message if the error cannot be encoded, e.g., because it has
+// attributes that are not JSON serializable.
+func errorJSON(err error) json.RawMessage {
+	if err == nil {
+		return nil
+	}
+	data, e := core.MarshalErrorJSON(err)
+	if e != nil {
+		data, _ = json.Marshal(map[string]string{"message": err.Error()})
+	}
+	return data
+}
@@ -0,0 +1,212 @@
+package healthhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core/healthcheck"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newReporter(t *testing.T) healthcheck.Reporter {
+	registry := healthcheck.NewRegistry()
+	check := func(name string, status healthcheck.Status, err error, tags ...string) healthcheck.Check {
+		return healthcheck.Check{
+			ID:   ulid.Make(),
+			Name: name,
+			Tags: tags,
+			Run: func(time.Duration) healthcheck.Result {
+				return healthcheck.Result{Status: status, Message: name, Err: err}
+			},
+		}
+	}
+	algod := check("algod", healthcheck.Green, nil, "algorand")
+	algod.Categories = []healthcheck.Category{healthcheck.Liveness, healthcheck.Readiness}
+	db := check("db", healthcheck.Red, errors.New("connection refused"), "db")
+	db.Categories = []healthcheck.Category{healthcheck.Startup}
+	if err := registry.Register(
+		algod,
+		check("kmd", healthcheck.Yellow, nil, "algorand"),
+		db,
+	); err != nil {
+		t.Fatal(err)
+	}
+	return healthcheck.OnDemand{Registry: registry}
+}
+
+type report struct {
+	Status     string            `json:"status"`
+	Categories map[string]string `json:"categories"`
+	Duration   string            `json:"duration"`
+	Checks     []struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Status   string `json:"status"`
+		Message  string `json:"message"`
+		Duration string `json:"duration"`
+		Err      *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"checks"`
+}
+
+func get(t *testing.T, handler http.Handler, target string) (*httptest.ResponseRecorder, report) {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	t.Log(w.Body.String())
+	var r report
+	if err := json.Unmarshal(w.Body.Bytes(), &r); err != nil {
+		t.Fatal(err)
+	}
+	return w, r
+}
+
+func TestNewServeMux(t *testing.T) {
+	mux := NewServeMux(newReporter(t))
+
+	for _, test := range []struct {
+		path   string
+		code   int
+		status string
+		checks int
+	}{
+		{HealthzPath, http.StatusServiceUnavailable, "Red", 3},
+		{LivezPath, http.StatusOK, "Green", 1},
+		{ReadyzPath, http.StatusOK, "Yellow", 2},
+		{StartupzPath, http.StatusServiceUnavailable, "Red", 1},
+	} {
+		w, r := get(t, mux, test.path)
+		if w.Code != test.code || r.Status != test.status || len(r.Checks) != test.checks {
+			t.Errorf("%v: unexpected response: %v: %+v", test.path, w.Code, r)
+		}
+		if w.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("%v: unexpected content type: %v", test.path, w.Header().Get("Content-Type"))
+		}
+	}
+
+	t.Run("category status", func(t *testing.T) {
+		_, r := get(t, mux, HealthzPath)
+		expected := map[string]string{"Liveness": "Green", "Readiness": "Yellow", "Startup": "Red"}
+		for category, status := range expected {
+			if r.Categories[category] != status {
+				t.Errorf("%v status should be %v: %v", category, status, r.Categories)
+			}
+		}
+	})
+
+	t.Run("filter by tag", func(t *testing.T) {
+		w, r := get(t, mux, HealthzPath+"?tag=algorand")
+		if w.Code != http.StatusOK || r.Status != "Yellow" || len(r.Checks) != 2 {
+			t.Errorf("unexpected response: %v: %+v", w.Code, r)
+		}
+	})
+
+	t.Run("filter by check name", func(t *testing.T) {
+		w, r := get(t, mux, HealthzPath+"?check=algod")
+		if w.Code != http.StatusOK || r.Status != "Green" || len(r.Checks) != 1 {
+			t.Fatalf("unexpected response: %v: %+v", w.Code, r)
+		}
+		check := r.Checks[0]
+		if check.Name != "algod" || check.Message != "algod" || check.Status != "Green" || check.Duration == "" {
+			t.Errorf("unexpected check result: %+v", check)
+		}
+	})
+
+	t.Run("check error", func(t *testing.T) {
+		_, r := get(t, mux, HealthzPath+"?check=db")
+		if err := r.Checks[0].Err; err == nil || err.Message != "connection refused" {
+			t.Errorf("check error was not reported: %+v", r.Checks[0])
+		}
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, HealthzPath, nil))
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("unexpected status code: %v", w.Code)
+		}
+	})
+}
+
+func TestNewHandler_BaseSelector(t *testing.T) {
+	handler := NewHandler(newReporter(t), healthcheck.Selector{Tags: []string{"algorand"}})
+	w, r := get(t, handler, "/?check=kmd")
+	if w.Code != http.StatusOK || len(r.Checks) != 1 || r.Checks[0].Name != "kmd" {
+		t.Errorf("unexpected response: %v: %+v", w.Code, r)
+	}
+	if _, r := get(t, handler, "/?check=db"); len(r.Checks) != 0 {
+		t.Errorf("db check should not be selected: %+v", r)
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	for status, expected := range map[healthcheck.Status]int{
+		healthcheck.Green:  http.StatusOK,
+		healthcheck.Yellow: http.StatusOK,
+		healthcheck.Red:    http.StatusServiceUnavailable,
+	} {
+		if code := StatusCode(status); code != expected {
+			t.Errorf("%v should map to %v, but was %v", status, expected, code)
+		}
+	}
+}
+
+func TestNewHandler_RootCause(t *testing.T) {
+	registry := healthcheck.NewRegistry()
+	algod := healthcheck.Check{
+		ID:   ulid.Make(),
+		Name: "algod",
+		Run: func(time.Duration) healthcheck.Result {
+			return healthcheck.Result{Status: healthcheck.Red, Message: "connection refused"}
+		},
+	}
+	kmd := healthcheck.Check{
+		ID:        ulid.Make(),
+		Name:      "kmd",
+		DependsOn: []ulid.ULID{algod.ID},
+		Run: func(time.Duration) healthcheck.Result {
+			return healthcheck.Result{Status: healthcheck.Green}
+		},
+	}
+	if err := registry.Register(algod, kmd); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewHandler(healthcheck.OnDemand{Registry: registry}, healthcheck.Selector{})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?check=kmd&check=algod", nil))
+	t.Log(w.Body.String())
+	var r struct {
+		Checks []struct {
+			Name       string `json:"name"`
+			Impacted   bool   `json:"impacted"`
+			RootCauses []struct {
+				ID      string `json:"id"`
+				Name    string `json:"name"`
+				Message string `json:"message"`
+			} `json:"root_causes"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &r); err != nil {
+		t.Fatal(err)
+	}
+	for _, check := range r.Checks {
+		switch check.Name {
+		case "algod":
+			if check.Impacted || len(check.RootCauses) != 0 {
+				t.Errorf("algod is the root cause: %+v", check)
+			}
+		case "kmd":
+			if !check.Impacted || len(check.RootCauses) != 1 {
+				t.Fatalf("kmd should be impacted: %+v", check)
+			}
+			rootCause := check.RootCauses[0]
+			if rootCause.ID != algod.ID.String() || rootCause.Name != "algod" || rootCause.Message != "connection refused" {
+				t.Errorf("root cause does not match: %+v", rootCause)
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package healthcheck
+
+import (
+	"github.com/benbjohnson/clock"
+	"github.com/oklog/ulid/v2"
+	"sync"
+	"time"
+)
+
+// DefaultHistorySize is the number of results kept per check, unless overridden by SchedulerConfig.HistorySize
+const DefaultHistorySize = 100
+
+// History keeps a bounded history of the most recent results per check.
+//
+// It is safe for concurrent use.
+type History struct {
+	size  int
+	clock clock.Clock // used to evaluate windows
+
+	lock    sync.RWMutex
+	results map[ulid.ULID]*resultRing
+}
+
+// NewHistory constructs a new History that keeps up to the specified number of results per check.
+// If size is not positive, then DefaultHistorySize is used.
+func NewHistory(size int) *History {
+	return newHistory(size, clock.New())
+}
+
+func newHistory(size int, clock clock.Clock) *History {
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+	return &History{
+		size:    size,
+		clock:   clock,
+		results: make(map[ulid.ULID]*resultRing),
+	}
+}
+
+// Record adds the result to the check's history, evicting the oldest result if the history is full
+func (h *History) Record(result CheckResult) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	ring, ok := h.results[result.Check.ID]
+	if !ok {
+		ring = &resultRing{results: make([]CheckResult, 0, h.size)}
+		h.results[result.Check.ID] = ring
+	}
+	ring.add(result)
+}
+
+// Results returns the check's results that were recorded within the window, ordered from oldest to newest.
+// If window is zero, then all recorded results are returned.
+func (h *History) Results(id ulid.ULID, window time.Duration) []CheckResult {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	ring, ok := h.results[id]
+	if !ok {
+		return nil
+	}
+	results := ring.list()
+	if window <= 0 {
+		return results
+	}
+	since := h.clock.Now().Add(-window)
+	for i, result := range results {
+		if !result.Time.Before(since) {
+			return results[i:]
+		}
+	}
+	return nil
+}
+
+// Stats summarizes check results
+type Stats struct {
+	Count int // number of results
+	// Uptime is the percentage of results that are healthy, i.e., not Red
+	Uptime float64
+	// MeanDuration is the mean check duration
+	MeanDuration time.Duration
+	// Transitions is the number of times the status changed
+	Transitions int
+}
+
+// Stats summarizes the check's results that were recorded within the window.
+// If window is zero, then all recorded results are summarized.
+func (h *History) Stats(id ulid.ULID, window time.Duration) Stats {
+	return summarize(h.Results(id, window))
+}
+
+func summarize(results []CheckResult) Stats {
+	if len(results) == 0 {
+		return Stats{}
+	}
+	var healthy int
+	var duration time.Duration
+	var transitions int
+	for i, result := range results {
+		if result.Status != Red {
+			healthy++
+		}
+		duration += result.Duration
+		if i > 0 && result.Status != results[i-1].Status {
+			transitions++
+		}
+	}
+	return Stats{
+		Count:        len(results),
+		Uptime:       100 * float64(healthy) / float64(len(results)),
+		MeanDuration: duration / time.Duration(len(results)),
+		Transitions:  transitions,
+	}
+}
+
+// FlapDetection defines when a check is unstable, i.e., flapping
+type FlapDetection struct {
+	// Window is the period of time that is evaluated
+	Window time.Duration
+	// MaxTransitions is the maximum number of status changes allowed within the window before the check is reported as
+	// unstable
+	MaxTransitions int
+}
+
+// Unstable reports whether the check changed status more often than allowed within the flap detection window
+func (h *History) Unstable(id ulid.ULID, detection FlapDetection) bool {
+	return h.Stats(id, detection.Window).Transitions > detection.MaxTransitions
+}
+
+// resultRing is a fixed capacity ring buffer
+type resultRing struct {
+	results []CheckResult
+	next    int // index of the next slot to overwrite once the ring is full
+}
+
+func (r *resultRing) add(result CheckResult) {
+	if len(r.results) < cap(r.results) {
+		r.results = append(r.results, result)
+		return
+	}
+	r.results[r.next] = result
+	r.next = (r.next + 1) % len(r.results)
+}
+
+// list returns a copy of the results ordered from oldest to newest
+func (r *resultRing) list() []CheckResult {
+	results := make([]CheckResult, 0, len(r.results))
+	results = append(results, r.results[r.next:]...)
+	return append(results, r.results[:r.next]...)
+}
@@ -0,0 +1,75 @@
+package healthcheck
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	check := newCheck("foo", green)
+	result := func(status Status, duration time.Duration, age time.Duration) CheckResult {
+		return CheckResult{
+			Check:  check,
+			Result: Result{Status: status, Duration: duration},
+			Time:   time.Now().Add(-age),
+		}
+	}
+
+	history := NewHistory(4)
+	if results := history.Results(check.ID, 0); len(results) != 0 {
+		t.Errorf("history should be empty: %v", results)
+	}
+	for _, r := range []CheckResult{
+		result(Green, time.Millisecond, time.Hour),
+		result(Red, 2*time.Millisecond, 4*time.Minute),
+		result(Green, 3*time.Millisecond, 3*time.Minute),
+		result(Red, 4*time.Millisecond, 2*time.Minute),
+		result(Yellow, 5*time.Millisecond, time.Minute),
+	} {
+		history.Record(r)
+	}
+
+	t.Run("oldest result is evicted", func(t *testing.T) {
+		results := history.Results(check.ID, 0)
+		if len(results) != 4 {
+			t.Fatalf("history should be bounded: %v", len(results))
+		}
+		for i, status := range []Status{Red, Green, Red, Yellow} {
+			if results[i].Status != status {
+				t.Errorf("results should be ordered from oldest to newest: %v", results)
+			}
+		}
+	})
+
+	t.Run("window", func(t *testing.T) {
+		if results := history.Results(check.ID, 150*time.Second); len(results) != 2 {
+			t.Errorf("expected 2 results within the window: %v", results)
+		}
+		if results := history.Results(check.ID, time.Second); len(results) != 0 {
+			t.Errorf("expected no results within the window: %v", results)
+		}
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		stats := history.Stats(check.ID, 0)
+		expected := Stats{Count: 4, Uptime: 50, MeanDuration: 3500 * time.Microsecond, Transitions: 3}
+		if stats != expected {
+			t.Errorf("expected %+v, but was %+v", expected, stats)
+		}
+		if stats := history.Stats(newCheck("bar", green).ID, 0); stats != (Stats{}) {
+			t.Errorf("stats should be empty: %+v", stats)
+		}
+	})
+
+	t.Run("flap detection", func(t *testing.T) {
+		if !history.Unstable(check.ID, FlapDetection{Window: 10 * time.Minute, MaxTransitions: 2}) {
+			t.Error("check should be unstable")
+		}
+		if history.Unstable(check.ID, FlapDetection{Window: 150 * time.Second, MaxTransitions: 2}) {
+			t.Error("check should be stable within the shorter window")
+		}
+		if history.Unstable(check.ID, FlapDetection{Window: 10 * time.Minute, MaxTransitions: 3}) {
+			t.Error("check should be stable when more transitions are allowed")
+		}
+	})
+}
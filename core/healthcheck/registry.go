@@ -0,0 +1,173 @@
+package healthcheck
+
+import (
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Check is a registered HealthCheck
+type Check struct {
+	ID          ulid.ULID // unique check ID
+	Name        string    // human friendly name
+	Description string    // describes what is being checked
+	Tags        []string  // used to select checks, e.g., "algod", "kmd"
+	// Categories define what the check is for. If none are specified, then the check is a Readiness check.
+	Categories []Category
+	// DependsOn lists the IDs of the checks that this check depends on. If a dependency is Red, then this check is not
+	// run - it is marked as impacted instead, and the failed upstream checks are reported as the root cause.
+	DependsOn []ulid.ULID
+	// Timeout is passed into the HealthCheck. If zero, then the Runner's default timeout is used.
+	Timeout time.Duration
+	// Interval is how often the Scheduler runs the check. If zero, then the Scheduler's default interval is used.
+	Interval time.Duration
+	// Critical checks determine the overall health status when aggregated by Criticality
+	Critical bool
+	Run      HealthCheck
+}
+
+// InCategory reports whether the check belongs to the specified category
+func (c Check) InCategory(category Category) bool {
+	if len(c.Categories) == 0 {
+		return category == Readiness
+	}
+	return slices.Contains(c.Categories, category)
+}
+
+// HasTag reports whether the check is tagged with the specified tag
+func (c Check) HasTag(tag string) bool {
+	return slices.Contains(c.Tags, tag)
+}
+
+func (c Check) validate() error {
+	if c.ID == (ulid.ULID{}) {
+		return fmt.Errorf("health check ID is required: %v", c.Name)
+	}
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("health check name is required: %v", c.ID)
+	}
+	if c.Run == nil {
+		return fmt.Errorf("health check func is required: %v[%v]", c.Name, c.ID)
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("health check timeout must not be negative: %v[%v]: %v", c.Name, c.ID, c.Timeout)
+	}
+	if c.Interval < 0 {
+		return fmt.Errorf("health check interval must not be negative: %v[%v]: %v", c.Name, c.ID, c.Interval)
+	}
+	for _, category := range c.Categories {
+		if !category.valid() {
+			return fmt.Errorf("invalid health check category: %v[%v]: %v", c.Name, c.ID, category)
+		}
+	}
+	return nil
+}
+
+// Registry is a catalog of health checks keyed by ID.
+//
+// It is safe for concurrent use.
+type Registry struct {
+	lock   sync.RWMutex
+	checks map[ulid.ULID]Check
+}
+
+// NewRegistry constructs a new empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		checks: make(map[ulid.ULID]Check),
+	}
+}
+
+// Register adds the health checks to the registry.
+//
+// Registration is all or nothing: if any check is invalid, its ID is already registered, it depends on a check that is
+// not registered, or the check dependencies form a cycle, then nothing is registered and the errors are returned.
+// Checks can depend on checks that are registered in the same call.
+func (r *Registry) Register(checks ...Check) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var errs []error
+	pending := make(map[ulid.ULID]Check, len(checks))
+	for _, check := range checks {
+		if err := check.validate(); err != nil {
+			errs = append(errs, errInvalidHealthCheck(err))
+			continue
+		}
+		if _, ok := r.checks[check.ID]; ok {
+			errs = append(errs, errDuplicateHealthCheckID(check.ID))
+			continue
+		}
+		if _, ok := pending[check.ID]; ok {
+			errs = append(errs, errDuplicateHealthCheckID(check.ID))
+			continue
+		}
+		check.Tags = slices.Clone(check.Tags)
+		check.Categories = slices.Clone(check.Categories)
+		check.DependsOn = slices.Clone(check.DependsOn)
+		pending[check.ID] = check
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	graph := maps.Clone(r.checks)
+	maps.Copy(graph, pending)
+	for _, check := range pending {
+		for _, dependency := range check.DependsOn {
+			if _, ok := graph[dependency]; !ok {
+				errs = append(errs, errUnknownHealthCheckDependency(check.Name, dependency))
+			}
+		}
+	}
+	if cycle := findCycle(graph); cycle != nil {
+		errs = append(errs, errHealthCheckDependencyCycle(formatCycle(cycle)))
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for id, check := range pending {
+		r.checks[id] = check
+	}
+	return nil
+}
+
+// Lookup returns the health check for the specified ID
+func (r *Registry) Lookup(id ulid.ULID) (check Check, ok bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	check, ok = r.checks[id]
+	return
+}
+
+// List returns the registered health checks sorted by name and then ID.
+//
+// If tags are specified, then only checks that have at least one of the tags are returned.
+func (r *Registry) List(tags ...string) []Check {
+	return r.Select(Selector{Tags: tags})
+}
+
+// Select returns the selected health checks sorted by name and then ID
+func (r *Registry) Select(selector Selector) []Check {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	checks := make([]Check, 0, len(r.checks))
+	for _, check := range r.checks {
+		if selector.Matches(check) {
+			checks = append(checks, check)
+		}
+	}
+	slices.SortFunc(checks, func(a, b Check) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return a.ID.Compare(b.ID)
+	})
+	return checks
+}
@@ -0,0 +1,103 @@
+package healthcheck
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"slices"
+	"testing"
+	"time"
+)
+
+func green(time.Duration) Result {
+	return Result{Status: Green}
+}
+
+func newCheck(name string, run HealthCheck, tags ...string) Check {
+	return Check{
+		ID:   ulid.Make(),
+		Name: name,
+		Tags: tags,
+		Run:  run,
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := NewRegistry()
+	foo := newCheck("foo", green, "algod")
+	bar := newCheck("bar", green, "kmd")
+	if err := registry.Register(foo, bar); err != nil {
+		t.Fatal(err)
+	}
+	if check, ok := registry.Lookup(foo.ID); !ok || check.Name != "foo" {
+		t.Errorf("foo check should be registered: %v", check)
+	}
+
+	t.Run("duplicate ID", func(t *testing.T) {
+		baz := newCheck("baz", green)
+		dup := foo
+		dup.Name = "dup"
+		err := registry.Register(baz, dup)
+		if !errors.Is(err, core.Error{ID: ErrDuplicateHealthCheckID}) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := registry.Lookup(baz.ID); ok {
+			t.Error("registration should be all or nothing")
+		}
+	})
+
+	t.Run("invalid checks", func(t *testing.T) {
+		negativeTimeout := newCheck("negative timeout", green)
+		negativeTimeout.Timeout = -time.Second
+		negativeInterval := newCheck("negative interval", green)
+		negativeInterval.Interval = -time.Second
+		invalidCategory := newCheck("invalid category", green)
+		invalidCategory.Categories = []Category{Startup, 0}
+		for name, check := range map[string]Check{
+			"missing ID":        {Name: "foo", Run: green},
+			"missing name":      {ID: ulid.Make(), Run: green},
+			"missing func":      {ID: ulid.Make(), Name: "foo"},
+			"negative timeout":  negativeTimeout,
+			"negative interval": negativeInterval,
+			"invalid category":  invalidCategory,
+		} {
+			err := registry.Register(check)
+			if !errors.Is(err, core.Error{ID: ErrInvalidHealthCheck}) {
+				t.Errorf("%v: unexpected error: %v", name, err)
+			}
+		}
+	})
+}
+
+func TestRegistry_List(t *testing.T) {
+	registry := NewRegistry()
+	checks := []Check{
+		newCheck("c", green, "algod"),
+		newCheck("a", green, "kmd"),
+		newCheck("b", green, "algod", "kmd"),
+	}
+	if err := registry.Register(checks...); err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(checks []Check) (names []string) {
+		for _, check := range checks {
+			names = append(names, check.Name)
+		}
+		return
+	}
+	for _, test := range []struct {
+		tags     []string
+		expected []string
+	}{
+		{nil, []string{"a", "b", "c"}},
+		{[]string{"algod"}, []string{"b", "c"}},
+		{[]string{"kmd"}, []string{"a", "b"}},
+		{[]string{"kmd", "algod"}, []string{"a", "b", "c"}},
+		{[]string{"indexer"}, nil},
+	} {
+		if actual := names(registry.List(test.tags...)); !slices.Equal(actual, test.expected) {
+			t.Errorf("tags %v: expected %v, but was %v", test.tags, test.expected, actual)
+		}
+	}
+}
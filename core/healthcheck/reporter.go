@@ -0,0 +1,85 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// Selector selects health checks by tag and name.
+//
+// The zero value selects all checks.
+type Selector struct {
+	// Tags selects checks that have at least one of the tags
+	Tags []string
+	// Names selects checks by name
+	Names []string
+	// Categories selects checks that belong to at least one of the categories
+	Categories []Category
+}
+
+// Matches reports whether the check is selected
+func (s Selector) Matches(check Check) bool {
+	if len(s.Tags) > 0 && !slices.ContainsFunc(s.Tags, check.HasTag) {
+		return false
+	}
+	if len(s.Names) > 0 && !slices.Contains(s.Names, check.Name) {
+		return false
+	}
+	if len(s.Categories) > 0 && !slices.ContainsFunc(s.Categories, check.InCategory) {
+		return false
+	}
+	return true
+}
+
+// Reporter reports on the health of the selected checks
+//
+// The context bounds how long the report may take, e.g., when the checks are run on demand.
+type Reporter interface {
+	Report(ctx context.Context, selector Selector) Report
+}
+
+// OnDemand is a Reporter that runs the selected registered checks each time a report is requested
+type OnDemand struct {
+	Registry *Registry
+	Runner   Runner
+}
+
+// Report runs the selected checks, which are abandoned if the context is done before they complete
+func (r OnDemand) Report(ctx context.Context, selector Selector) Report {
+	return r.Runner.Run(ctx, r.Registry.Select(selector)...)
+}
+
+// AwaitGreen polls the reporter on the specified interval until the selected checks are Green, or the context is done.
+// Each poll is bounded by the context, i.e., a poll does not overrun the context deadline.
+//
+// If the context is done first, then ErrHealthChecksNotGreen is returned with the latest report that completed before
+// the context was done. Its cause is the context error joined with the errors reported by the checks that are not
+// Green.
+func AwaitGreen(ctx context.Context, reporter Reporter, selector Selector, interval time.Duration) (Report, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var report Report
+	for polls := 0; ; polls++ {
+		latest := reporter.Report(ctx, selector)
+		if latest.Status == Green {
+			return latest, nil
+		}
+		// a poll that was cut short by the context reports the context error instead of the check errors
+		if polls == 0 || ctx.Err() == nil {
+			report = latest
+		}
+		select {
+		case <-ctx.Done():
+			errs := []error{ctx.Err()}
+			for _, result := range report.Results {
+				if result.Status != Green && result.Err != nil {
+					errs = append(errs, result.Err)
+				}
+			}
+			return report, errHealthChecksNotGreen(report.Status, errors.Join(errs...))
+		case <-ticker.C:
+		}
+	}
+}
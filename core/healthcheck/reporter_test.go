@@ -0,0 +1,96 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSelector_Matches(t *testing.T) {
+	check := newCheck("algod", green, "algorand", "node")
+	check.Categories = []Category{Liveness, Startup}
+	for _, test := range []struct {
+		selector Selector
+		expected bool
+	}{
+		{Selector{}, true},
+		{Selector{Tags: []string{"node"}}, true},
+		{Selector{Tags: []string{"kmd", "algorand"}}, true},
+		{Selector{Tags: []string{"kmd"}}, false},
+		{Selector{Names: []string{"algod"}}, true},
+		{Selector{Names: []string{"kmd"}}, false},
+		{Selector{Tags: []string{"node"}, Names: []string{"algod"}}, true},
+		{Selector{Tags: []string{"kmd"}, Names: []string{"algod"}}, false},
+		{Selector{Categories: []Category{Startup}}, true},
+		{Selector{Categories: []Category{Readiness}}, false},
+		{Selector{Categories: []Category{Readiness, Liveness}}, true},
+	} {
+		if test.selector.Matches(check) != test.expected {
+			t.Errorf("%+v: expected %v", test.selector, test.expected)
+		}
+	}
+}
+
+func TestOnDemand_Report(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(
+		newCheck("algod", status(Green), "algorand"),
+		newCheck("kmd", status(Red), "algorand"),
+		newCheck("db", status(Yellow)),
+	); err != nil {
+		t.Fatal(err)
+	}
+	reporter := OnDemand{Registry: registry}
+
+	report := reporter.Report(context.Background(), Selector{})
+	if report.Status != Red || len(report.Results) != 3 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	report = reporter.Report(context.Background(), Selector{Names: []string{"algod", "db"}})
+	if report.Status != Yellow || len(report.Results) != 2 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+func TestAwaitGreen(t *testing.T) {
+	var runs atomic.Int32
+	starting := newCheck("starting", func(time.Duration) Result {
+		if runs.Add(1) < 3 {
+			return Result{Status: Red, Err: errors.New("still starting")}
+		}
+		return Result{Status: Green}
+	})
+	starting.Categories = []Category{Startup}
+	failing := newCheck("failing", func(time.Duration) Result {
+		return Result{Status: Red, Err: errors.New("BOOM")}
+	})
+	failing.Categories = []Category{Startup}
+	registry := NewRegistry()
+	if err := registry.Register(starting, failing, newCheck("not ready", status(Red))); err != nil {
+		t.Fatal(err)
+	}
+	reporter := OnDemand{Registry: registry}
+
+	t.Run("checks become Green", func(t *testing.T) {
+		report, err := AwaitGreen(context.Background(), reporter, Selector{Names: []string{"starting"}}, time.Millisecond)
+		if err != nil || report.Status != Green {
+			t.Errorf("unexpected result: %v: %v", report.Status, err)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		report, err := AwaitGreen(ctx, reporter, Selector{Categories: []Category{Startup}}, time.Millisecond)
+		if !errors.Is(err, core.Error{ID: ErrHealthChecksNotGreen}) || report.Status != Red {
+			t.Fatalf("unexpected result: %v: %v", report.Status, err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "BOOM") {
+			t.Errorf("cause should include the context error and the check errors: %v", err)
+		}
+	})
+}
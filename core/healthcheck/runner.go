@@ -0,0 +1,235 @@
+package healthcheck
+
+import (
+	"context"
+	"github.com/benbjohnson/clock"
+	"github.com/oklog/ulid/v2"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is the timeout used for checks that do not specify one, unless overridden by Runner.DefaultTimeout
+const DefaultTimeout = 10 * time.Second
+
+// CheckResult is the Result of running a Check
+type CheckResult struct {
+	Check Check
+	Result
+	Time time.Time // when the check was started
+	// Impacted means the check was not run because one or more of its dependencies failed
+	Impacted bool
+	// RootCauses are the IDs of the failed upstream checks that the check is impacted by
+	RootCauses []ulid.ULID
+}
+
+// Report is the outcome of running a set of health checks
+type Report struct {
+	Status Status // aggregate status
+	// Categories is the aggregate status per category, for each category that has at least one result
+	Categories map[Category]Status
+	Time       time.Time
+	Duration   time.Duration
+	Results    []CheckResult
+}
+
+// newReport aggregates the results overall and per category
+func newReport(results []CheckResult, aggregate Aggregator) Report {
+	categories := make(map[Category]Status)
+	for category := range categoryNames {
+		var categoryResults []CheckResult
+		for _, result := range results {
+			if result.Check.InCategory(category) {
+				categoryResults = append(categoryResults, result)
+			}
+		}
+		if len(categoryResults) > 0 {
+			categories[category] = aggregate(categoryResults)
+		}
+	}
+	return Report{
+		Status:     aggregate(results),
+		Categories: categories,
+		Results:    results,
+	}
+}
+
+// Aggregator computes the overall status for a set of check results
+type Aggregator func(results []CheckResult) Status
+
+// WorstOf aggregates the results to the worst status, e.g., if any check is Red, then the overall status is Red.
+// If there are no results, then the status is Green.
+func WorstOf(results []CheckResult) Status {
+	status := Green
+	for _, result := range results {
+		status = max(status, result.Status)
+	}
+	return status
+}
+
+// Criticality aggregates the results weighted by check criticality: a Red non-critical check degrades the overall
+// status to Yellow, while a Red critical check makes the overall status Red.
+// If there are no results, then the status is Green.
+func Criticality(results []CheckResult) Status {
+	status := Green
+	for _, result := range results {
+		switch {
+		case result.Status == Red && !result.Check.Critical:
+			status = max(status, Yellow)
+		default:
+			status = max(status, result.Status)
+		}
+	}
+	return status
+}
+
+// Runner runs health checks.
+//
+// Each check is run with its own timeout, which is passed into the HealthCheck. The timeout is bounded by the context
+// deadline. If the check does not return within its timeout, then it is abandoned and its result is Red with an
+// ErrHealthCheckTimeout error. If the context is done before the check returns, then the check is abandoned and its
+// result is Red with the context error. If the check panics, then its
+// result is Red with an ErrHealthCheckPanic error. The result Duration is always measured by the Runner.
+//
+// A result with an invalid status, e.g., the zero value, is treated as Red.
+//
+// The zero value is ready to use.
+type Runner struct {
+	// DefaultTimeout is used for checks that do not specify a timeout. If zero, then DefaultTimeout is used.
+	DefaultTimeout time.Duration
+	// Aggregate computes the report status. If nil, then WorstOf is used.
+	Aggregate Aggregator
+	// Observer, if set, is notified of each check result, e.g., to export metrics. It is called from the goroutine that
+	// produced the result, i.e., it must be safe for concurrent use.
+	Observer func(result CheckResult)
+	// Clock is used to time the checks. If nil, then the system clock is used.
+	Clock clock.Clock
+}
+
+func (r Runner) clock() clock.Clock {
+	if r.Clock != nil {
+		return r.Clock
+	}
+	return clock.New()
+}
+
+func (r Runner) observe(result CheckResult) {
+	if r.Observer != nil {
+		r.Observer(result)
+	}
+}
+
+func (r Runner) timeout(check Check) time.Duration {
+	switch {
+	case check.Timeout > 0:
+		return check.Timeout
+	case r.DefaultTimeout > 0:
+		return r.DefaultTimeout
+	default:
+		return DefaultTimeout
+	}
+}
+
+func (r Runner) aggregator() Aggregator {
+	if r.Aggregate != nil {
+		return r.Aggregate
+	}
+	return WorstOf
+}
+
+// Run runs the checks concurrently and returns the report once all checks have completed or timed out.
+//
+// Check dependencies are evaluated as a DAG: a check is run after its dependencies have completed, and if any of its
+// dependencies are Red, then the check is marked as impacted instead of being run. Dependencies that are not being run
+// are ignored. If the check dependencies form a cycle, then all dependencies are ignored.
+//
+// Results are returned in the same order as the checks.
+func (r Runner) Run(ctx context.Context, checks ...Check) Report {
+	runnerClock := r.clock()
+	start := runnerClock.Now()
+	results := make([]CheckResult, len(checks))
+	done := make([]chan struct{}, len(checks))
+	graph := make(map[ulid.ULID]Check, len(checks))
+	index := make(map[ulid.ULID]int, len(checks))
+	for i, check := range checks {
+		done[i] = make(chan struct{})
+		graph[check.ID] = check
+		index[check.ID] = i
+	}
+	acyclic := findCycle(graph) == nil
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			defer close(done[i])
+			var dependencies []CheckResult
+			for _, id := range check.DependsOn {
+				if j, ok := index[id]; ok && acyclic {
+					<-done[j]
+					dependencies = append(dependencies, results[j])
+				}
+			}
+			if result, ok := impacted(check, dependencies, runnerClock.Now()); ok {
+				r.observe(result)
+				results[i] = result
+				return
+			}
+			results[i] = r.RunCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+	report := newReport(results, r.aggregator())
+	report.Time = start
+	report.Duration = runnerClock.Since(start)
+	return report
+}
+
+// RunCheck runs the check and waits for it to complete or time out, or for the context to be done.
+//
+// Check dependencies are not evaluated.
+func (r Runner) RunCheck(ctx context.Context, check Check) CheckResult {
+	result := r.runCheck(ctx, check)
+	r.observe(result)
+	return result
+}
+
+func (r Runner) runCheck(ctx context.Context, check Check) CheckResult {
+	runnerClock := r.clock()
+	start := runnerClock.Now()
+	if err := ctx.Err(); err != nil {
+		return CheckResult{Check: check, Result: Result{Message: err.Error(), Status: Red, Err: err}, Time: start}
+	}
+	timeout := r.timeout(check)
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = min(timeout, time.Until(deadline))
+	}
+	done := make(chan Result, 1) // buffered to ensure abandoned checks do not leak goroutines
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err := errHealthCheckPanic(check.Name, recovered)
+				done <- Result{Message: err.Error(), Status: Red, Err: err}
+			}
+		}()
+		done <- check.Run(timeout)
+	}()
+
+	timer := runnerClock.Timer(timeout)
+	defer timer.Stop()
+	var result Result
+	select {
+	case result = <-done:
+		result.Duration = runnerClock.Since(start)
+	case <-timer.C:
+		err := errHealthCheckTimeout(check.Name, timeout)
+		result = Result{Message: err.Error(), Status: Red, Duration: runnerClock.Since(start), Err: err}
+	case <-ctx.Done():
+		err := ctx.Err()
+		result = Result{Message: err.Error(), Status: Red, Duration: runnerClock.Since(start), Err: err}
+	}
+	if !result.Status.valid() {
+		result.Status = Red
+	}
+	return CheckResult{Check: check, Result: result, Time: start}
+}
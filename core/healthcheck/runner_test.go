@@ -0,0 +1,225 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"sync"
+	"testing"
+	"time"
+)
+
+func status(status Status) HealthCheck {
+	return func(time.Duration) Result {
+		return Result{Status: status}
+	}
+}
+
+func TestRunner_RunCheck(t *testing.T) {
+	runner := Runner{DefaultTimeout: 50 * time.Millisecond}
+
+	t.Run("timeout is passed into the check", func(t *testing.T) {
+		check := newCheck("foo", func(timeout time.Duration) Result {
+			if timeout != 10*time.Millisecond {
+				return Result{Status: Red, Message: timeout.String()}
+			}
+			return Result{Status: Green, Message: "ok"}
+		})
+		check.Timeout = 10 * time.Millisecond
+		result := runner.RunCheck(context.Background(), check)
+		if result.Status != Green || result.Message != "ok" {
+			t.Errorf("unexpected result: %v", result)
+		}
+		if result.Duration <= 0 {
+			t.Error("duration should be recorded")
+		}
+		if result.Check.ID != check.ID || result.Time.IsZero() {
+			t.Errorf("check result does not match: %v", result)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		check := newCheck("slow", func(timeout time.Duration) Result {
+			time.Sleep(time.Second)
+			return Result{Status: Green}
+		})
+		start := time.Now()
+		result := runner.RunCheck(context.Background(), check)
+		if time.Since(start) > 500*time.Millisecond {
+			t.Error("runner should not wait for a check that timed out")
+		}
+		if result.Status != Red || !errors.Is(result.Err, core.Error{ID: ErrHealthCheckTimeout}) {
+			t.Errorf("unexpected result: %v", result)
+		}
+		if timeout, ok := core.AttrValue[time.Duration](result.Err, "timeout"); !ok || timeout != runner.DefaultTimeout {
+			t.Errorf("timeout attribute does not match: %v", timeout)
+		}
+	})
+
+	t.Run("overrun", func(t *testing.T) {
+		check := newCheck("overrun", func(timeout time.Duration) Result {
+			time.Sleep(2 * timeout)
+			return Result{Status: Green}
+		})
+		check.Timeout = time.Millisecond
+		result := Runner{}.RunCheck(context.Background(), check)
+		if result.Status != Red || !errors.Is(result.Err, core.Error{ID: ErrHealthCheckTimeout}) {
+			t.Errorf("unexpected result: %v", result)
+		}
+	})
+
+	t.Run("timeout is bounded by the context deadline", func(t *testing.T) {
+		check := newCheck("slow", func(timeout time.Duration) Result {
+			time.Sleep(time.Second)
+			return Result{Status: Green}
+		})
+		check.Timeout = time.Hour
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		result := runner.RunCheck(ctx, check)
+		if time.Since(start) > 500*time.Millisecond {
+			t.Error("runner should not wait past the context deadline")
+		}
+		if result.Status != Red || !errors.Is(result.Err, core.Error{ID: ErrHealthCheckTimeout}) {
+			t.Errorf("unexpected result: %v", result)
+		}
+		if timeout, ok := core.AttrValue[time.Duration](result.Err, "timeout"); !ok || timeout > 10*time.Millisecond {
+			t.Errorf("timeout should be bounded by the context deadline: %v", timeout)
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		check := newCheck("slow", func(timeout time.Duration) Result {
+			cancel()
+			time.Sleep(time.Second)
+			return Result{Status: Green}
+		})
+		result := runner.RunCheck(ctx, check)
+		if result.Status != Red || !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("unexpected result: %v", result)
+		}
+		if result = runner.RunCheck(ctx, check); result.Status != Red || !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("check should not be run once the context is done: %v", result)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		result := runner.RunCheck(context.Background(), newCheck("panic", func(time.Duration) Result {
+			panic("BOOM")
+		}))
+		if result.Status != Red || !errors.Is(result.Err, core.Error{ID: ErrHealthCheckPanic}) {
+			t.Errorf("unexpected result: %v", result)
+		}
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		result := runner.RunCheck(context.Background(), newCheck("invalid", status(0)))
+		if result.Status != Red {
+			t.Errorf("unexpected result: %v", result)
+		}
+	})
+}
+
+func TestRunner_Run(t *testing.T) {
+	checks := []Check{
+		newCheck("green", status(Green)),
+		newCheck("yellow", status(Yellow)),
+		newCheck("slow", func(timeout time.Duration) Result {
+			time.Sleep(20 * time.Millisecond)
+			return Result{Status: Green}
+		}),
+		newCheck("slow", func(timeout time.Duration) Result {
+			time.Sleep(20 * time.Millisecond)
+			return Result{Status: Green}
+		}),
+	}
+	checks[0].Categories = []Category{Liveness}
+	report := Runner{}.Run(context.Background(), checks...)
+	if report.Status != Yellow {
+		t.Errorf("status should be Yellow: %v", report.Status)
+	}
+	if report.Categories[Liveness] != Green || report.Categories[Readiness] != Yellow {
+		t.Errorf("category status does not match: %v", report.Categories)
+	}
+	if _, ok := report.Categories[Startup]; ok {
+		t.Errorf("there should be no Startup status when there are no Startup checks: %v", report.Categories)
+	}
+	if report.Duration >= 40*time.Millisecond {
+		t.Errorf("checks should run concurrently: %v", report.Duration)
+	}
+	for i, result := range report.Results {
+		if result.Check.ID != checks[i].ID {
+			t.Errorf("results should be in the same order as the checks: %v", result.Check.Name)
+		}
+	}
+}
+
+func TestAggregators(t *testing.T) {
+	results := func(critical bool, statuses ...Status) (results []CheckResult) {
+		for _, status := range statuses {
+			results = append(results, CheckResult{Check: Check{Critical: critical}, Result: Result{Status: status}})
+		}
+		return
+	}
+	for _, test := range []struct {
+		name        string
+		results     []CheckResult
+		worstOf     Status
+		criticality Status
+	}{
+		{"no results", nil, Green, Green},
+		{"all green", results(true, Green, Green), Green, Green},
+		{"yellow", results(false, Green, Yellow), Yellow, Yellow},
+		{"non-critical red", results(false, Green, Red), Red, Yellow},
+		{"critical red", results(true, Green, Red), Red, Red},
+	} {
+		if status := WorstOf(test.results); status != test.worstOf {
+			t.Errorf("%v: WorstOf status should be %v, but was %v", test.name, test.worstOf, status)
+		}
+		if status := Criticality(test.results); status != test.criticality {
+			t.Errorf("%v: Criticality status should be %v, but was %v", test.name, test.criticality, status)
+		}
+	}
+}
+
+func TestParseCategory(t *testing.T) {
+	for _, category := range []Category{Liveness, Readiness, Startup} {
+		if parsed, err := ParseCategory(category.String()); err != nil || parsed != category {
+			t.Errorf("failed to parse category: %v", category)
+		}
+	}
+	if _, err := ParseCategory("Shutdown"); err == nil {
+		t.Error("parsing an invalid category should fail")
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	for _, status := range []Status{Green, Yellow, Red} {
+		if parsed, err := ParseStatus(status.String()); err != nil || parsed != status {
+			t.Errorf("failed to parse status: %v", status)
+		}
+	}
+	if _, err := ParseStatus("Blue"); err == nil {
+		t.Error("parsing an invalid status should fail")
+	}
+}
+
+func TestRunner_Observer(t *testing.T) {
+	var lock sync.Mutex
+	observed := make(map[string]CheckResult)
+	runner := Runner{Observer: func(result CheckResult) {
+		lock.Lock()
+		defer lock.Unlock()
+		observed[result.Check.Name] = result
+	}}
+	algod := newCheck("algod", status(Red))
+	kmd := newCheck("kmd", green)
+	kmd.DependsOn = []ulid.ULID{algod.ID}
+	runner.Run(context.Background(), algod, kmd)
+	if len(observed) != 2 || observed["algod"].Status != Red || !observed["kmd"].Impacted {
+		t.Errorf("all results should be observed, including impacted results: %v", observed)
+	}
+}
@@ -0,0 +1,243 @@
+package healthcheck
+
+import (
+	"context"
+	"github.com/benbjohnson/clock"
+	"github.com/oklog/ulid/v2"
+	"sync"
+	"time"
+)
+
+// DefaultInterval is the interval used for checks that do not specify one, unless overridden by
+// SchedulerConfig.DefaultInterval
+const DefaultInterval = 30 * time.Second
+
+// StatusChange is published when a check's status changes
+type StatusChange struct {
+	Previous CheckResult
+	Current  CheckResult
+}
+
+// SchedulerConfig configures a Scheduler
+type SchedulerConfig struct {
+	// Runner is used to run the checks
+	Runner Runner
+	// DefaultInterval is used for checks that do not specify an interval. If zero, then DefaultInterval is used.
+	DefaultInterval time.Duration
+	// HistorySize is the number of results kept per check. If zero, then DefaultHistorySize is used.
+	HistorySize int
+	// Clock is used to schedule the checks. If nil, then the system clock is used. It is also used to time the checks,
+	// unless the Runner has its own Clock, and to evaluate History windows.
+	// Tests can use a mock clock to make scheduling deterministic - see clock.NewMock.
+	Clock clock.Clock
+}
+
+// Scheduler runs each registered check in the background on its own interval and caches the latest result.
+//
+// The most recent results for each check are kept in its History.
+//
+// Scheduler is a Reporter that reports the cached results, which makes it suitable for serving health probes when the
+// checks are too expensive to run on each request.
+//
+// It is safe for concurrent use.
+type Scheduler struct {
+	registry *Registry
+	config   SchedulerConfig
+	clock    clock.Clock
+
+	lock    sync.RWMutex
+	results map[ulid.ULID]CheckResult
+	history *History
+	cancel  context.CancelFunc
+	done    sync.WaitGroup
+
+	subscribersLock sync.RWMutex
+	subscribers     map[chan StatusChange]struct{}
+}
+
+// NewScheduler constructs a new Scheduler for the checks in the registry
+func NewScheduler(registry *Registry, config SchedulerConfig) *Scheduler {
+	schedulerClock := config.Clock
+	if schedulerClock == nil {
+		schedulerClock = clock.New()
+	}
+	if config.Runner.Clock == nil {
+		config.Runner.Clock = schedulerClock
+	}
+	return &Scheduler{
+		registry:    registry,
+		config:      config,
+		clock:       schedulerClock,
+		results:     make(map[ulid.ULID]CheckResult),
+		history:     newHistory(config.HistorySize, schedulerClock),
+		subscribers: make(map[chan StatusChange]struct{}),
+	}
+}
+
+func (s *Scheduler) interval(check Check) time.Duration {
+	switch {
+	case check.Interval > 0:
+		return check.Interval
+	case s.config.DefaultInterval > 0:
+		return s.config.DefaultInterval
+	default:
+		return DefaultInterval
+	}
+}
+
+// Start runs all registered checks once and waits for them to complete, which ensures that results are available
+// once Start returns. The first run is bounded by the context - see Runner.Run. Each check is then run in the
+// background on its own interval until the Scheduler is stopped.
+// When a scheduled check runs, its dependencies are evaluated using their latest results - see Check.DependsOn.
+//
+// Only checks that are registered when the Scheduler is started are scheduled.
+// Starting a Scheduler that is already started is a no-op.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.lock.Lock()
+	if s.cancel != nil {
+		s.lock.Unlock()
+		return
+	}
+	scheduleCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.lock.Unlock()
+
+	checks := s.registry.List()
+	for _, result := range s.config.Runner.Run(ctx, checks...).Results {
+		s.update(result)
+	}
+	for _, check := range checks {
+		// the ticker is created before Start returns to ensure that no ticks are missed
+		ticker := s.clock.Ticker(s.interval(check))
+		s.done.Add(1)
+		go s.schedule(scheduleCtx, check, ticker)
+	}
+}
+
+// Stop stops running the checks and waits for any checks that are running to complete or time out.
+//
+// Cached results are retained.
+func (s *Scheduler) Stop() {
+	s.lock.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.lock.Unlock()
+	if cancel != nil {
+		cancel()
+		s.done.Wait()
+	}
+}
+
+func (s *Scheduler) schedule(ctx context.Context, check Check, ticker *clock.Ticker) {
+	defer s.done.Done()
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.update(s.run(check))
+		}
+	}
+}
+
+// run runs the check, unless it is impacted by the latest results of its dependencies
+func (s *Scheduler) run(check Check) CheckResult {
+	s.lock.RLock()
+	var dependencies []CheckResult
+	for _, id := range check.DependsOn {
+		if result, ok := s.results[id]; ok {
+			dependencies = append(dependencies, result)
+		}
+	}
+	s.lock.RUnlock()
+	if result, ok := impacted(check, dependencies, s.clock.Now()); ok {
+		s.config.Runner.observe(result)
+		return result
+	}
+	// scheduled checks are bounded by their own timeouts
+	return s.config.Runner.RunCheck(context.Background(), check)
+}
+
+func (s *Scheduler) update(result CheckResult) {
+	s.lock.Lock()
+	previous, ok := s.results[result.Check.ID]
+	s.results[result.Check.ID] = result
+	s.lock.Unlock()
+	s.history.Record(result)
+
+	if ok && previous.Status != result.Status {
+		s.publish(StatusChange{Previous: previous, Current: result})
+	}
+}
+
+// Result returns the latest result for the specified check
+func (s *Scheduler) Result(id ulid.ULID) (result CheckResult, ok bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	result, ok = s.results[id]
+	return
+}
+
+// Runner returns the Runner that is used to run the checks
+func (s *Scheduler) Runner() Runner {
+	return s.config.Runner
+}
+
+// History returns the check result history
+func (s *Scheduler) History() *History {
+	return s.history
+}
+
+// Report reports the latest results for the selected checks.
+//
+// Checks that have not been run yet are not included in the report. The report Time is when the report was produced,
+// and its Duration is zero because no checks are run.
+func (s *Scheduler) Report(_ context.Context, selector Selector) Report {
+	checks := s.registry.Select(selector)
+	results := make([]CheckResult, 0, len(checks))
+	s.lock.RLock()
+	for _, check := range checks {
+		if result, ok := s.results[check.ID]; ok {
+			results = append(results, result)
+		}
+	}
+	s.lock.RUnlock()
+	report := newReport(results, s.config.Runner.aggregator())
+	report.Time = s.clock.Now()
+	return report
+}
+
+// Subscribe subscribes to check status changes, i.e., transitions from one status to another, such as Green -> Red.
+// The first result for a check is not a status change.
+//
+// Status changes are delivered on a channel with the specified buffer size. Delivery does not block the Scheduler:
+// if the channel buffer is full, then the status change is dropped for the subscriber.
+// The returned unsubscribe func closes the channel.
+func (s *Scheduler) Subscribe(buffer int) (changes <-chan StatusChange, unsubscribe func()) {
+	ch := make(chan StatusChange, buffer)
+	s.subscribersLock.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subscribersLock.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.subscribersLock.Lock()
+			defer s.subscribersLock.Unlock()
+			delete(s.subscribers, ch)
+			close(ch)
+		})
+	}
+}
+
+func (s *Scheduler) publish(change StatusChange) {
+	s.subscribersLock.RLock()
+	defer s.subscribersLock.RUnlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- change:
+		default:
+		}
+	}
+}
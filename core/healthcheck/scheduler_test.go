@@ -0,0 +1,117 @@
+package healthcheck
+
+import (
+	"context"
+	"github.com/benbjohnson/clock"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForRuns waits until the scheduler has recorded the expected number of results for the check
+func waitForRuns(t *testing.T, scheduler *Scheduler, check Check, expected int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for scheduler.History().Stats(check.ID, 0).Count < expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("%v check should have run %v times: %+v", check.Name, expected, scheduler.History().Stats(check.ID, 0))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	mock := clock.NewMock()
+	var current atomic.Int32
+	current.Store(int32(Green))
+	flipping := newCheck("flipping", func(time.Duration) Result {
+		return Result{Status: Status(current.Load())}
+	}, "algod")
+	flipping.Interval = 5 * time.Second
+	static := newCheck("static", status(Yellow), "kmd")
+	static.Interval = time.Hour
+
+	registry := NewRegistry()
+	if err := registry.Register(flipping, static); err != nil {
+		t.Fatal(err)
+	}
+	scheduler := NewScheduler(registry, SchedulerConfig{Clock: mock})
+	changes, unsubscribe := scheduler.Subscribe(10)
+	defer unsubscribe()
+
+	if _, ok := scheduler.Result(flipping.ID); ok {
+		t.Error("there should be no results before the scheduler is started")
+	}
+	scheduler.Start(context.Background())
+	defer scheduler.Stop()
+
+	t.Run("results are cached when started", func(t *testing.T) {
+		report := scheduler.Report(context.Background(), Selector{})
+		if report.Status != Yellow || len(report.Results) != 2 {
+			t.Errorf("unexpected report: %+v", report)
+		}
+		if !report.Time.Equal(mock.Now()) {
+			t.Errorf("report time should be set using the scheduler clock: %v", report.Time)
+		}
+		for _, result := range report.Results {
+			if !result.Time.Equal(mock.Now()) {
+				t.Errorf("result time should be set using the scheduler clock: %v", result.Time)
+			}
+		}
+		report = scheduler.Report(context.Background(), Selector{Tags: []string{"algod"}})
+		if report.Status != Green || len(report.Results) != 1 {
+			t.Errorf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("checks run on their own interval", func(t *testing.T) {
+		for i := 2; i <= 4; i++ {
+			mock.Add(flipping.Interval)
+			waitForRuns(t, scheduler, flipping, i)
+		}
+		if results := scheduler.History().Results(flipping.ID, flipping.Interval); len(results) != 2 {
+			t.Errorf("history window should be evaluated using the scheduler clock: %v", len(results))
+		}
+		if stats := scheduler.History().Stats(static.ID, 0); stats.Count != 1 {
+			t.Errorf("static check should have only run when the scheduler was started: %+v", stats)
+		}
+	})
+
+	t.Run("status changes are published", func(t *testing.T) {
+		current.Store(int32(Red))
+		mock.Add(flipping.Interval)
+		select {
+		case change := <-changes:
+			if change.Previous.Status != Green || change.Current.Status != Red || change.Current.Check.ID != flipping.ID {
+				t.Errorf("unexpected status change: %+v", change)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("status change was not published")
+		}
+		if result, _ := scheduler.Result(flipping.ID); result.Status != Red {
+			t.Errorf("cached result was not updated: %v", result)
+		}
+		if stats := scheduler.History().Stats(flipping.ID, 0); stats.Transitions != 1 || stats.Count != 5 {
+			t.Errorf("results should be recorded in the history: %+v", stats)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		scheduler.Stop()
+		count := scheduler.History().Stats(flipping.ID, 0).Count
+		mock.Add(flipping.Interval)
+		if stats := scheduler.History().Stats(flipping.ID, 0); stats.Count != count {
+			t.Errorf("checks should not run after the scheduler is stopped: %+v", stats)
+		}
+		if _, ok := scheduler.Result(flipping.ID); !ok {
+			t.Error("cached results should be retained")
+		}
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		unsubscribe()
+		unsubscribe()
+		for range changes {
+		}
+	})
+}
@@ -0,0 +1,58 @@
+// Code generated by errgen from errors.yaml. DO NOT EDIT.
+
+package retry
+
+import (
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"log/slog"
+)
+
+var (
+	ErrRetryAttemptsExhausted = ulid.MustParse("01M53ZM1DC1R8CK5NN11ES80AA")
+	ErrRetryCancelled         = ulid.MustParse("01M53ZM1DDA34XCNKCAB7M7Y30")
+)
+
+var errorDefinitions = []core.ErrorDefinition{
+	{
+		ID:          ErrRetryAttemptsExhausted,
+		Name:        "ErrRetryAttemptsExhausted",
+		Description: "operation failed on every attempt allowed by the retry policy - the failure is permanent, thus nested retries do not retry it",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/core/retry",
+		Permanent:   true,
+	},
+	{
+		ID:          ErrRetryCancelled,
+		Name:        "ErrRetryCancelled",
+		Description: "retrying was stopped because the context is done",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/core/retry",
+	},
+}
+
+func init() {
+	core.RegisterErrors(errorDefinitions...)
+}
+
+func errRetryAttemptsExhausted(attempts int, cause error) core.Error {
+	return core.Error{
+		ID:        ErrRetryAttemptsExhausted,
+		Name:      "ErrRetryAttemptsExhausted",
+		Err:       fmt.Errorf("operation failed after %v attempts", attempts),
+		Cause:     cause,
+		Permanent: true,
+	}.With(
+		slog.Int("attempts", attempts),
+	)
+}
+
+func errRetryCancelled(attempts int, cause error) core.Error {
+	return core.Error{
+		ID:    ErrRetryCancelled,
+		Name:  "ErrRetryCancelled",
+		Err:   fmt.Errorf("retrying was cancelled after %v attempts", attempts),
+		Cause: cause,
+	}.With(
+		slog.Int("attempts", attempts),
+	)
+}
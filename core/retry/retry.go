@@ -0,0 +1,116 @@
+// Package retry retries failed operations with exponential backoff and jitter.
+//
+// Whether a failed operation is retried is decided by the error's classification - see core.IsRetryable.
+package retry
+
+import (
+	"context"
+	"errors"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// MinInterval is the minimum backoff interval, which prevents retrying in a busy loop, e.g., when a policy's
+// InitialInterval is zero
+const MinInterval = time.Millisecond
+
+// Policy controls how failed operations are retried
+type Policy struct {
+	// MaxAttempts is the maximum number of times the operation is attempted, including the first attempt.
+	// If zero, then attempts are unlimited, i.e., the operation is retried until it succeeds, fails with an error that
+	// is not retryable, or the context is done.
+	MaxAttempts int
+	// InitialInterval is the backoff interval before the first retry. Backoff intervals are at least MinInterval.
+	InitialInterval time.Duration
+	// MaxInterval caps the backoff interval
+	MaxInterval time.Duration
+	// Multiplier is applied to the backoff interval after each retry
+	Multiplier float64
+	// Jitter randomizes the backoff interval by +/- the specified fraction of the interval, i.e., 0.2 means +/- 20%.
+	// Valid range is [0, 1].
+	Jitter float64
+	// Retryable reports whether the error is worth retrying. If nil, then core.IsRetryable is used.
+	Retryable func(err error) bool
+}
+
+// DefaultPolicy returns a Policy that makes at most 5 attempts, starting with a 100 msec backoff interval that doubles
+// after each retry up to 10 seconds, with +/- 20% jitter.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:     5,
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+	}
+}
+
+func (p Policy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return core.IsRetryable(err)
+}
+
+// Backoff returns the backoff interval to wait before the specified retry, where retry 1 is the first retry.
+// The backoff interval is at least MinInterval.
+func (p Policy) Backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	interval := float64(p.InitialInterval) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxInterval > 0 && interval > float64(p.MaxInterval) {
+		interval = float64(p.MaxInterval)
+	}
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		interval += interval * jitter * (2*rand.Float64() - 1)
+	}
+	return max(time.Duration(interval), MinInterval)
+}
+
+// Do runs the operation until it succeeds, or until the policy says to stop.
+//
+// Errors:
+//   - if the operation fails with an error that is not retryable, then that error is returned as is
+//   - ErrRetryAttemptsExhausted is returned with the last operation error as its cause when max attempts is reached.
+//     It is flagged as permanent, i.e., it is not retryable regardless of its cause, while core.CategoryOf still
+//     reports the category of its cause.
+//   - ErrRetryCancelled is returned when the context is done before the operation succeeds. Its cause is the
+//     context error joined with the last operation error.
+func Do(ctx context.Context, policy Policy, operation func(ctx context.Context) error) error {
+	_, err := DoValue(ctx, policy, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, operation(ctx)
+	})
+	return err
+}
+
+// DoValue is the same as Do, but for operations that return a value
+func DoValue[T any](ctx context.Context, policy Policy, operation func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return zero, errRetryCancelled(attempt-1, err)
+		}
+		result, err := operation(ctx)
+		if err == nil {
+			return result, nil
+		}
+		if !policy.retryable(err) {
+			return zero, err
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return zero, errRetryAttemptsExhausted(attempt, err)
+		}
+
+		timer := time.NewTimer(policy.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, errRetryCancelled(attempt, errors.Join(ctx.Err(), err))
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"github.com/oysterpack/oysterpack-smart-go/core/coretest"
+	"testing"
+	"time"
+)
+
+var (
+	errUnavailable = core.Error{
+		ID:       ulid.MustParse("01HH3QZ7Y4M2C6B8N1T5R9V0KX"),
+		Name:     "ErrUnavailable",
+		Err:      errors.New("service unavailable"),
+		Category: core.Unavailable,
+	}
+	errNotFound = core.Error{
+		ID:       ulid.MustParse("01HH3R0H2P7W5K9D3F6Q8S1NZB"),
+		Name:     "ErrNotFound",
+		Err:      errors.New("not found"),
+		Category: core.NotFound,
+	}
+)
+
+func testPolicy() Policy {
+	policy := DefaultPolicy()
+	policy.InitialInterval = time.Millisecond
+	policy.MaxInterval = 5 * time.Millisecond
+	return policy
+}
+
+func TestDo(t *testing.T) {
+	t.Run("retry until success", func(t *testing.T) {
+		attempts := 0
+		err := Do(context.Background(), testPolicy(), func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errUnavailable
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if attempts != 3 {
+			t.Errorf("expected 3 attempts, but was %v", attempts)
+		}
+	})
+
+	t.Run("errors that are not retryable are returned as is", func(t *testing.T) {
+		attempts := 0
+		err := Do(context.Background(), testPolicy(), func(ctx context.Context) error {
+			attempts++
+			return errNotFound
+		})
+		if !errors.Is(err, errNotFound) || errors.Is(err, core.Error{ID: ErrRetryAttemptsExhausted}) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if attempts != 1 {
+			t.Errorf("expected 1 attempt, but was %v", attempts)
+		}
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		attempts := 0
+		err := Do(context.Background(), testPolicy(), func(ctx context.Context) error {
+			attempts++
+			return errUnavailable
+		})
+		if !errors.Is(err, core.Error{ID: ErrRetryAttemptsExhausted}) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !errors.Is(err, errUnavailable) {
+			t.Error("the last error should be the cause")
+		}
+		if attempts != testPolicy().MaxAttempts {
+			t.Errorf("expected %v attempts, but was %v", testPolicy().MaxAttempts, attempts)
+		}
+		if core.IsRetryable(err) {
+			t.Error("exhausted retries should not be retryable")
+		}
+		if category := core.CategoryOf(err); category != core.Unavailable {
+			t.Errorf("category should be the category of the last error: %v", category)
+		}
+	})
+
+	t.Run("nested retries do not multiply attempts", func(t *testing.T) {
+		attempts := 0
+		err := Do(context.Background(), testPolicy(), func(ctx context.Context) error {
+			return Do(ctx, testPolicy(), func(ctx context.Context) error {
+				attempts++
+				return errUnavailable
+			})
+		})
+		if !errors.Is(err, core.Error{ID: ErrRetryAttemptsExhausted}) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if attempts != testPolicy().MaxAttempts {
+			t.Errorf("expected %v attempts, but was %v", testPolicy().MaxAttempts, attempts)
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		policy := testPolicy()
+		policy.MaxAttempts = 0
+		policy.InitialInterval = time.Hour
+		policy.MaxInterval = time.Hour
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := Do(ctx, policy, func(ctx context.Context) error {
+			return errUnavailable
+		})
+		if !errors.Is(err, core.Error{ID: ErrRetryCancelled}) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errUnavailable) {
+			t.Errorf("cause should include the context error and the last error: %v", err)
+		}
+	})
+
+	t.Run("custom retryable", func(t *testing.T) {
+		policy := testPolicy()
+		policy.Retryable = func(err error) bool {
+			return errors.Is(err, errNotFound)
+		}
+		attempts := 0
+		err := Do(context.Background(), policy, func(ctx context.Context) error {
+			attempts++
+			return errNotFound
+		})
+		if !errors.Is(err, core.Error{ID: ErrRetryAttemptsExhausted}) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if attempts != policy.MaxAttempts {
+			t.Errorf("expected %v attempts, but was %v", policy.MaxAttempts, attempts)
+		}
+	})
+}
+
+func TestDoValue(t *testing.T) {
+	attempts := 0
+	value, err := DoValue(context.Background(), testPolicy(), func(ctx context.Context) (int, error) {
+		attempts++
+		if attempts < 2 {
+			return 0, errUnavailable
+		}
+		return 42, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 42 {
+		t.Errorf("unexpected value: %v", value)
+	}
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	policy := Policy{
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     time.Second,
+		Multiplier:      2,
+	}
+	for retry, expected := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		6: time.Second,
+	} {
+		if backoff := policy.Backoff(retry); backoff != expected {
+			t.Errorf("retry %v backoff should be %v, but was %v", retry, expected, backoff)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := policy.Backoff(1)
+		if backoff < 50*time.Millisecond || backoff > 150*time.Millisecond {
+			t.Fatalf("backoff is out of the jitter range: %v", backoff)
+		}
+	}
+
+	t.Run("zero policy backs off at least MinInterval", func(t *testing.T) {
+		if backoff := (Policy{}).Backoff(1); backoff != MinInterval {
+			t.Errorf("backoff should be %v, but was %v", MinInterval, backoff)
+		}
+	})
+}
+
+func TestErrorDefinitions(t *testing.T) {
+	coretest.CheckErrorDefinitions(t, errorDefinitions...)
+}
@@ -2,9 +2,11 @@ package account
 
 import (
 	"context"
+	"fmt"
 	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
 	"github.com/algorand/go-algorand-sdk/v2/transaction"
 	"github.com/algorand/go-algorand-sdk/v2/types"
+	"net/http"
 )
 
 // Address is the human-readable Algorand account [address] which maps to the account's public key
@@ -15,10 +17,15 @@ type Address string
 // GetAuthAddr looks up the authorized signing account for the specified account address.
 //
 // If the account is not rekeyed, then the authorized account is itself.
+//
+// Errors:
+//   - ErrGetAuthAddrRejected if algod rejected the request, e.g., because the address is invalid
+//   - ErrAccountNotFound if algod does not know the account
+//   - ErrGetAuthAddrFailed if algod could not be reached or failed to process the request, which is retryable
 func GetAuthAddr(algodClient *algod.Client, address Address) (authAddr Address, err error) {
 	account, err := algodClient.AccountInformation(string(address)).Do(context.Background())
 	if err != nil {
-		return "", errGetAuthAddrFailed(err)
+		return "", getAuthAddrError(address, err)
 	}
 	if account.AuthAddr == "" {
 		return address, nil
@@ -26,6 +33,23 @@ func GetAuthAddr(algodClient *algod.Client, address Address) (authAddr Address,
 	return Address(account.AuthAddr), nil
 }
 
+// getAuthAddrError classifies an account lookup failure by its cause.
+//
+// algod client errors are formatted as "HTTP <status code>: <response body>". 4xx responses mean the request was
+// rejected, i.e., retrying will not help. Transport failures and 5xx responses are transient.
+func getAuthAddrError(address Address, err error) error {
+	var statusCode int
+	if _, scanErr := fmt.Sscanf(err.Error(), "HTTP %d:", &statusCode); scanErr == nil {
+		switch {
+		case statusCode == http.StatusNotFound:
+			return errAccountNotFound(address, err)
+		case statusCode >= 400 && statusCode < 500:
+			return errGetAuthAddrRejected(address, err)
+		}
+	}
+	return errGetAuthAddrFailed(err)
+}
+
 // MakeRekeyTransaction constructs a transaction to rekey the account from the specified address to the specified address
 func MakeRekeyTransaction(algodClient *algod.Client, from, to Address) (types.Transaction, error) {
 	sp, err := algodClient.SuggestedParams().Do(context.Background())
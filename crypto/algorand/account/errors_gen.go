@@ -0,0 +1,170 @@
+// Code generated by errgen from errors.yaml. DO NOT EDIT.
+
+package account
+
+import (
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"log/slog"
+)
+
+var (
+	ErrGetAuthAddrFailed        = ulid.MustParse("01HGB4K37WVFBQFPM0RND212YS")
+	ErrGetAuthAddrRejected      = ulid.MustParse("01M545HCXSTNJ4WWJ4KKQ7ED46")
+	ErrAccountNotFound          = ulid.MustParse("01M545HCXSTNJ4WWJ4KPWW5EB9")
+	ErrAccountAlreadyRekeyed    = ulid.MustParse("01HGB4MAW16F6GXHCKC3399BZ1")
+	ErrGetSuggestedParamsFailed = ulid.MustParse("01HGTE11YD3XAX2KGZVGWZFWRY")
+	ErrMakePaymentTxn           = ulid.MustParse("01HGTEPA5PWKCTXM682GRBJCVB")
+	ErrSignTransactions         = ulid.MustParse("01HGTETWSHXMFNSTFWMS5JDZRZ")
+	ErrSettingRekeyTo           = ulid.MustParse("01HGTF3KG43JWPT8SBCF7W67WX")
+)
+
+var errorDefinitions = []core.ErrorDefinition{
+	{
+		ID:          ErrGetAuthAddrFailed,
+		Name:        "ErrGetAuthAddrFailed",
+		Description: "failed to look up the account's authorized signing address because algod could not be reached or failed to process the request",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/account",
+		Category:    core.Unavailable,
+	},
+	{
+		ID:          ErrGetAuthAddrRejected,
+		Name:        "ErrGetAuthAddrRejected",
+		Description: "algod rejected the account's authorized signing address lookup, e.g., because the address is invalid",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/account",
+		Category:    core.InvalidArgument,
+	},
+	{
+		ID:          ErrAccountNotFound,
+		Name:        "ErrAccountNotFound",
+		Description: "algod does not know the account",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/account",
+		Category:    core.NotFound,
+	},
+	{
+		ID:          ErrAccountAlreadyRekeyed,
+		Name:        "ErrAccountAlreadyRekeyed",
+		Description: "account has already been rekeyed",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/account",
+		Category:    core.FailedPrecondition,
+	},
+	{
+		ID:          ErrGetSuggestedParamsFailed,
+		Name:        "ErrGetSuggestedParamsFailed",
+		Description: "failed to get the suggested transaction params from algod",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/account",
+		Category:    core.Unavailable,
+	},
+	{
+		ID:          ErrMakePaymentTxn,
+		Name:        "ErrMakePaymentTxn",
+		Description: "failed to construct a payment transaction",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/account",
+		Category:    core.InvalidArgument,
+	},
+	{
+		ID:          ErrSignTransactions,
+		Name:        "ErrSignTransactions",
+		Description: "failed to sign transactions",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/account",
+	},
+	{
+		ID:          ErrSettingRekeyTo,
+		Name:        "ErrSettingRekeyTo",
+		Description: "failed to set the rekeyTo field on a transaction",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/account",
+		Category:    core.InvalidArgument,
+	},
+}
+
+func init() {
+	core.RegisterErrors(errorDefinitions...)
+}
+
+func errGetAuthAddrFailed(cause error) core.Error {
+	return core.Error{
+		ID:       ErrGetAuthAddrFailed,
+		Name:     "ErrGetAuthAddrFailed",
+		Err:      errors.New("failed to get account auth address"),
+		Cause:    cause,
+		Category: core.Unavailable,
+	}
+}
+
+func errGetAuthAddrRejected(address Address, cause error) core.Error {
+	return core.Error{
+		ID:       ErrGetAuthAddrRejected,
+		Name:     "ErrGetAuthAddrRejected",
+		Err:      fmt.Errorf("failed to get account auth address: %v", address),
+		Cause:    cause,
+		Category: core.InvalidArgument,
+	}.With(
+		slog.String("address", string(address)),
+	)
+}
+
+func errAccountNotFound(address Address, cause error) core.Error {
+	return core.Error{
+		ID:       ErrAccountNotFound,
+		Name:     "ErrAccountNotFound",
+		Err:      fmt.Errorf("account not found: %v", address),
+		Cause:    cause,
+		Category: core.NotFound,
+	}.With(
+		slog.String("address", string(address)),
+	)
+}
+
+func errAccountAlreadyRekeyed(address Address) core.Error {
+	return core.Error{
+		ID:       ErrAccountAlreadyRekeyed,
+		Name:     "ErrAccountAlreadyRekeyed",
+		Err:      fmt.Errorf("account has already been rekeyed: %v", address),
+		Category: core.FailedPrecondition,
+	}.With(
+		slog.String("address", string(address)),
+	)
+}
+
+func errGetSuggestedParamsFailed(cause error) core.Error {
+	return core.Error{
+		ID:       ErrGetSuggestedParamsFailed,
+		Name:     "ErrGetSuggestedParamsFailed",
+		Err:      errors.New("failed to get suggested params for constructing a new transaction"),
+		Cause:    cause,
+		Category: core.Unavailable,
+	}
+}
+
+func errMakePaymentTxn(cause error) core.Error {
+	return core.Error{
+		ID:       ErrMakePaymentTxn,
+		Name:     "ErrMakePaymentTxn",
+		Err:      errors.New("failed to construct payment transaction"),
+		Cause:    cause,
+		Category: core.InvalidArgument,
+	}
+}
+
+func errSignTransactions(cause error) core.Error {
+	return core.Error{
+		ID:    ErrSignTransactions,
+		Name:  "ErrSignTransactions",
+		Err:   errors.New("failed to sign transactions"),
+		Cause: cause,
+	}
+}
+
+func errSettingRekeyTo(address Address, cause error) core.Error {
+	return core.Error{
+		ID:       ErrSettingRekeyTo,
+		Name:     "ErrSettingRekeyTo",
+		Err:      fmt.Errorf("failed to set the rekeyTo field on the transaction: %v", address),
+		Cause:    cause,
+		Category: core.InvalidArgument,
+	}.With(
+		slog.String("address", string(address)),
+	)
+}
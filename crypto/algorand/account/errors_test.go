@@ -0,0 +1,40 @@
+package account
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"github.com/oysterpack/oysterpack-smart-go/core/coretest"
+	"net/url"
+	"testing"
+)
+
+func TestErrorDefinitions(t *testing.T) {
+	coretest.CheckErrorDefinitions(t, errorDefinitions...)
+}
+
+func TestGetAuthAddrError(t *testing.T) {
+	const address = Address("foo")
+	for _, test := range []struct {
+		name      string
+		err       error
+		id        ulid.ULID
+		category  core.Category
+		retryable bool
+	}{
+		{"invalid address", errors.New("HTTP 400: {\"message\":\"failed to parse the address\"}"), ErrGetAuthAddrRejected, core.InvalidArgument, false},
+		{"account not found", errors.New("HTTP 404: {\"message\":\"account not found\"}"), ErrAccountNotFound, core.NotFound, false},
+		{"algod failed", errors.New("HTTP 500: {\"message\":\"internal error\"}"), ErrGetAuthAddrFailed, core.Unavailable, true},
+		{"algod could not be reached", &url.Error{Op: "Get", URL: "http://localhost:4001", Err: errors.New("connection refused")}, ErrGetAuthAddrFailed, core.Unavailable, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := getAuthAddrError(address, test.err)
+			if !errors.Is(err, core.Error{ID: test.id}) || !errors.Is(err, test.err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if core.CategoryOf(err) != test.category || core.IsRetryable(err) != test.retryable {
+				t.Errorf("error is not classified as expected: %v: %v", core.CategoryOf(err), core.IsRetryable(err))
+			}
+		})
+	}
+}
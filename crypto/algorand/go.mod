@@ -11,6 +11,7 @@ require (
 require (
 	github.com/algorand/avm-abi v0.1.1 // indirect
 	github.com/algorand/go-codec/codec v1.1.10 // indirect
+	github.com/benbjohnson/clock v1.3.5 // indirect
 	github.com/google/go-querystring v1.1.0 // indirect
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/multierr v1.6.0 // indirect
@@ -0,0 +1,117 @@
+// Code generated by errgen from errors.yaml. DO NOT EDIT.
+
+package health
+
+import (
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrAlgodUnavailable   = ulid.MustParse("01M540E3GANKRT6VN7NW5YXJQ3")
+	ErrAlgodBehind        = ulid.MustParse("01M540E3GBS623CT6YDRRK08R2")
+	ErrKMDUnavailable     = ulid.MustParse("01M540E3GBS623CT6YDVQE8V8P")
+	ErrIndexerUnavailable = ulid.MustParse("01M540E3GBS623CT6YDYQS4CR5")
+	ErrIndexerBehind      = ulid.MustParse("01M540E3GBS623CT6YDZ072D7Q")
+)
+
+var errorDefinitions = []core.ErrorDefinition{
+	{
+		ID:          ErrAlgodUnavailable,
+		Name:        "ErrAlgodUnavailable",
+		Description: "algod node could not be reached",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/health",
+		Category:    core.Unavailable,
+	},
+	{
+		ID:          ErrAlgodBehind,
+		Name:        "ErrAlgodBehind",
+		Description: "algod node is catching up, or its last round lag or time since last round exceeds the threshold",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/health",
+		Category:    core.Unavailable,
+	},
+	{
+		ID:          ErrKMDUnavailable,
+		Name:        "ErrKMDUnavailable",
+		Description: "KMD could not be reached",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/health",
+		Category:    core.Unavailable,
+	},
+	{
+		ID:          ErrIndexerUnavailable,
+		Name:        "ErrIndexerUnavailable",
+		Description: "indexer could not be reached, or its database is not available",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/health",
+		Category:    core.Unavailable,
+	},
+	{
+		ID:          ErrIndexerBehind,
+		Name:        "ErrIndexerBehind",
+		Description: "indexer is migrating, or its round lag compared to algod exceeds the threshold",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/health",
+		Category:    core.Unavailable,
+	},
+}
+
+func init() {
+	core.RegisterErrors(errorDefinitions...)
+}
+
+func errAlgodUnavailable(cause error) core.Error {
+	return core.Error{
+		ID:       ErrAlgodUnavailable,
+		Name:     "ErrAlgodUnavailable",
+		Err:      errors.New("algod is unavailable"),
+		Cause:    cause,
+		Category: core.Unavailable,
+	}
+}
+
+func errAlgodBehind(lastRound uint64, lag uint64, timeSinceLastRound time.Duration) core.Error {
+	return core.Error{
+		ID:       ErrAlgodBehind,
+		Name:     "ErrAlgodBehind",
+		Err:      fmt.Errorf("algod is behind: last round = %v, round lag = %v, time since last round = %v", lastRound, lag, timeSinceLastRound),
+		Category: core.Unavailable,
+	}.With(
+		slog.Uint64("last_round", lastRound),
+		slog.Uint64("round_lag", lag),
+		slog.Duration("time_since_last_round", timeSinceLastRound),
+	)
+}
+
+func errKMDUnavailable(cause error) core.Error {
+	return core.Error{
+		ID:       ErrKMDUnavailable,
+		Name:     "ErrKMDUnavailable",
+		Err:      errors.New("KMD is unavailable"),
+		Cause:    cause,
+		Category: core.Unavailable,
+	}
+}
+
+func errIndexerUnavailable(cause error) core.Error {
+	return core.Error{
+		ID:       ErrIndexerUnavailable,
+		Name:     "ErrIndexerUnavailable",
+		Err:      errors.New("indexer is unavailable"),
+		Cause:    cause,
+		Category: core.Unavailable,
+	}
+}
+
+func errIndexerBehind(round uint64, lag uint64) core.Error {
+	return core.Error{
+		ID:       ErrIndexerBehind,
+		Name:     "ErrIndexerBehind",
+		Err:      fmt.Errorf("indexer is behind: round = %v, round lag = %v", round, lag),
+		Category: core.Unavailable,
+	}.With(
+		slog.Uint64("round", round),
+		slog.Uint64("round_lag", lag),
+	)
+}
@@ -0,0 +1,195 @@
+// Package health provides ready-made health checks for Algorand services: algod, KMD and indexer.
+package health
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/algorand/go-algorand-sdk/v2/client/kmd"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/indexer"
+	"github.com/oysterpack/oysterpack-smart-go/core/healthcheck"
+	"strings"
+	"time"
+)
+
+// Thresholds define when a measured value degrades the health status.
+//
+// If the value exceeds the Red threshold, then the status is Red. Otherwise, if the value exceeds the Yellow threshold,
+// then the status is Yellow. A zero threshold is disabled.
+type Thresholds[T cmp.Ordered] struct {
+	Yellow T
+	Red    T
+}
+
+func (t Thresholds[T]) status(value T) healthcheck.Status {
+	var zero T
+	switch {
+	case t.Red != zero && value > t.Red:
+		return healthcheck.Red
+	case t.Yellow != zero && value > t.Yellow:
+		return healthcheck.Yellow
+	default:
+		return healthcheck.Green
+	}
+}
+
+// AlgodConfig configures the algod health check
+type AlgodConfig struct {
+	// TimeSinceLastRound thresholds
+	//
+	// NOTE: a localnet running in dev mode only produces blocks when transactions are submitted, i.e., this check
+	// should be disabled for dev mode.
+	TimeSinceLastRound Thresholds[time.Duration]
+	// RoundLag thresholds, where the lag is measured against the LatestRound
+	RoundLag Thresholds[uint64]
+	// LatestRound returns the latest network round, e.g., as reported by a trusted node. If nil, then the round lag is
+	// not checked.
+	LatestRound func(ctx context.Context) (uint64, error)
+}
+
+// DefaultAlgodConfig returns an AlgodConfig that is Yellow if no new round has been produced for more than 10 seconds,
+// and Red after 1 minute. The round lag is Yellow above 5 rounds and Red above 20 rounds, but it is only checked once
+// LatestRound is set.
+func DefaultAlgodConfig() AlgodConfig {
+	return AlgodConfig{
+		TimeSinceLastRound: Thresholds[time.Duration]{Yellow: 10 * time.Second, Red: time.Minute},
+		RoundLag:           Thresholds[uint64]{Yellow: 5, Red: 20},
+	}
+}
+
+// Algod checks that algod is reachable and that it is caught up.
+//
+// The status is:
+//   - Red, if algod cannot be reached
+//   - at least Yellow, if algod is catching up
+//   - determined by the AlgodConfig thresholds for the time since the last round and the round lag
+func Algod(client *algod.Client, config AlgodConfig) healthcheck.HealthCheck {
+	return func(timeout time.Duration) healthcheck.Result {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		nodeStatus, err := client.Status().Do(ctx)
+		if err != nil {
+			return red(errAlgodUnavailable(err))
+		}
+
+		timeSinceLastRound := time.Duration(nodeStatus.TimeSinceLastRound)
+		status := config.TimeSinceLastRound.status(timeSinceLastRound)
+		message := fmt.Sprintf("last round = %v, time since last round = %v", nodeStatus.LastRound, timeSinceLastRound)
+		if nodeStatus.CatchupTime > 0 {
+			status = max(status, healthcheck.Yellow)
+			message += fmt.Sprintf(", catching up for %v", time.Duration(nodeStatus.CatchupTime))
+		}
+		var lag uint64
+		if config.LatestRound != nil {
+			latestRound, err := config.LatestRound(ctx)
+			if err != nil {
+				return healthcheck.Result{
+					Message: message + ", round lag is unknown",
+					Status:  max(status, healthcheck.Yellow),
+					Err:     err,
+				}
+			}
+			lag = roundLag(latestRound, nodeStatus.LastRound)
+			status = max(status, config.RoundLag.status(lag))
+			message += fmt.Sprintf(", round lag = %v", lag)
+		}
+
+		result := healthcheck.Result{Message: message, Status: status}
+		if status != healthcheck.Green {
+			result.Err = errAlgodBehind(nodeStatus.LastRound, lag, timeSinceLastRound)
+		}
+		return result
+	}
+}
+
+// KMD checks that KMD is reachable by requesting its supported API versions.
+//
+// NOTE: the KMD client does not support timeouts - if the check times out, then it is abandoned by the Runner.
+func KMD(client kmd.Client) healthcheck.HealthCheck {
+	return func(time.Duration) healthcheck.Result {
+		versions, err := client.Version()
+		if err != nil {
+			return red(errKMDUnavailable(err))
+		}
+		return healthcheck.Result{
+			Message: fmt.Sprintf("versions = %v", strings.Join(versions.Versions, ",")),
+			Status:  healthcheck.Green,
+		}
+	}
+}
+
+// IndexerConfig configures the indexer health check
+type IndexerConfig struct {
+	// RoundLag thresholds, where the lag is measured against algod's last round
+	RoundLag Thresholds[uint64]
+}
+
+// DefaultIndexerConfig returns an IndexerConfig that is Yellow if the indexer is more than 10 rounds behind algod, and
+// Red if it is more than 100 rounds behind.
+func DefaultIndexerConfig() IndexerConfig {
+	return IndexerConfig{
+		RoundLag: Thresholds[uint64]{Yellow: 10, Red: 100},
+	}
+}
+
+// Indexer checks the indexer's health and its round lag compared to algod.
+//
+// The status is:
+//   - Red, if the indexer cannot be reached or its database is not available
+//   - at least Yellow, if the indexer is migrating, or if algod cannot be reached, i.e., the round lag is unknown
+//   - determined by the IndexerConfig round lag thresholds
+func Indexer(client *indexer.Client, algodClient *algod.Client, config IndexerConfig) healthcheck.HealthCheck {
+	return func(timeout time.Duration) healthcheck.Result {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		health, err := client.HealthCheck().Do(ctx)
+		if err != nil {
+			return red(errIndexerUnavailable(err))
+		}
+		if !health.DbAvailable {
+			cause := errors.New(strings.Join(append([]string{"database is not available"}, health.Errors...), ": "))
+			return red(errIndexerUnavailable(cause))
+		}
+
+		status := healthcheck.Green
+		message := fmt.Sprintf("round = %v", health.Round)
+		if health.IsMigrating {
+			status = healthcheck.Yellow
+			message += ", migrating"
+		}
+		nodeStatus, err := algodClient.Status().Do(ctx)
+		if err != nil {
+			return healthcheck.Result{
+				Message: message + ", round lag is unknown",
+				Status:  max(status, healthcheck.Yellow),
+				Err:     errAlgodUnavailable(err),
+			}
+		}
+		lag := roundLag(nodeStatus.LastRound, health.Round)
+		status = max(status, config.RoundLag.status(lag))
+		message += fmt.Sprintf(", round lag = %v", lag)
+
+		result := healthcheck.Result{Message: message, Status: status}
+		if status != healthcheck.Green {
+			result.Err = errIndexerBehind(health.Round, lag)
+		}
+		return result
+	}
+}
+
+func roundLag(latestRound, round uint64) uint64 {
+	if round >= latestRound {
+		return 0
+	}
+	return latestRound - round
+}
+
+func red(err error) healthcheck.Result {
+	return healthcheck.Result{
+		Message: err.Error(),
+		Status:  healthcheck.Red,
+		Err:     err,
+	}
+}
@@ -0,0 +1,184 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/algorand/go-algorand-sdk/v2/client/kmd"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/indexer"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"github.com/oysterpack/oysterpack-smart-go/core/healthcheck"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var token = strings.Repeat("a", 64)
+
+// serve starts a local HTTP stand-in that responds to the specified path with the JSON encoded response
+func serve(t *testing.T, path string, response any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != path {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func algodClient(t *testing.T, status models.NodeStatus) *algod.Client {
+	client, err := algod.MakeClient(serve(t, "/v2/status", status).URL, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func unavailable(t *testing.T) string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+func TestAlgod(t *testing.T) {
+	config := DefaultAlgodConfig()
+	latestRound := func(round uint64) func(ctx context.Context) (uint64, error) {
+		return func(ctx context.Context) (uint64, error) {
+			return round, nil
+		}
+	}
+
+	for _, test := range []struct {
+		name        string
+		status      models.NodeStatus
+		latestRound func(ctx context.Context) (uint64, error)
+		expected    healthcheck.Status
+	}{
+		{"caught up", models.NodeStatus{LastRound: 100, TimeSinceLastRound: uint64(time.Second)}, nil, healthcheck.Green},
+		{"slow rounds", models.NodeStatus{LastRound: 100, TimeSinceLastRound: uint64(20 * time.Second)}, nil, healthcheck.Yellow},
+		{"stalled", models.NodeStatus{LastRound: 100, TimeSinceLastRound: uint64(2 * time.Minute)}, nil, healthcheck.Red},
+		{"catching up", models.NodeStatus{LastRound: 100, CatchupTime: uint64(time.Second)}, nil, healthcheck.Yellow},
+		{"small round lag", models.NodeStatus{LastRound: 100}, latestRound(103), healthcheck.Green},
+		{"round lag", models.NodeStatus{LastRound: 100}, latestRound(110), healthcheck.Yellow},
+		{"large round lag", models.NodeStatus{LastRound: 100}, latestRound(200), healthcheck.Red},
+		{"ahead of latest round", models.NodeStatus{LastRound: 100}, latestRound(90), healthcheck.Green},
+		{"unknown latest round", models.NodeStatus{LastRound: 100}, func(ctx context.Context) (uint64, error) {
+			return 0, errors.New("BOOM")
+		}, healthcheck.Yellow},
+	} {
+		config.LatestRound = test.latestRound
+		result := Algod(algodClient(t, test.status), config)(time.Second)
+		t.Logf("%v: %+v", test.name, result)
+		if result.Status != test.expected {
+			t.Errorf("%v: status should be %v, but was %v", test.name, test.expected, result.Status)
+		}
+		if result.Status != healthcheck.Green && result.Err == nil {
+			t.Errorf("%v: error should be reported", test.name)
+		}
+	}
+
+	t.Run("behind", func(t *testing.T) {
+		config.LatestRound = latestRound(200)
+		result := Algod(algodClient(t, models.NodeStatus{LastRound: 100}), config)(time.Second)
+		if !errors.Is(result.Err, core.Error{ID: ErrAlgodBehind}) {
+			t.Fatalf("unexpected error: %v", result.Err)
+		}
+		if lag, _ := core.AttrValue[uint64](result.Err, "round_lag"); lag != 100 {
+			t.Errorf("round lag attribute does not match: %v", lag)
+		}
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		client, err := algod.MakeClient(unavailable(t), token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Algod(client, config)(time.Second)
+		if result.Status != healthcheck.Red || !errors.Is(result.Err, core.Error{ID: ErrAlgodUnavailable}) {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+}
+
+func TestKMD(t *testing.T) {
+	client, err := kmd.MakeClient(serve(t, "/versions", kmd.VersionsResponse{Versions: []string{"v1"}}).URL, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := KMD(client)(time.Second); result.Status != healthcheck.Green {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	t.Run("unavailable", func(t *testing.T) {
+		client, err := kmd.MakeClient(unavailable(t), token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := KMD(client)(time.Second)
+		if result.Status != healthcheck.Red || !errors.Is(result.Err, core.Error{ID: ErrKMDUnavailable}) {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+}
+
+func TestIndexer(t *testing.T) {
+	indexerClient := func(health models.HealthCheck) *indexer.Client {
+		client, err := indexer.MakeClient(serve(t, "/health", health).URL, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+	algodClient := algodClient(t, models.NodeStatus{LastRound: 1000})
+
+	for _, test := range []struct {
+		name     string
+		health   models.HealthCheck
+		expected healthcheck.Status
+		err      error
+	}{
+		{"caught up", models.HealthCheck{Round: 998, DbAvailable: true}, healthcheck.Green, nil},
+		{"round lag", models.HealthCheck{Round: 950, DbAvailable: true}, healthcheck.Yellow, core.Error{ID: ErrIndexerBehind}},
+		{"large round lag", models.HealthCheck{Round: 800, DbAvailable: true}, healthcheck.Red, core.Error{ID: ErrIndexerBehind}},
+		{"migrating", models.HealthCheck{Round: 1000, DbAvailable: true, IsMigrating: true}, healthcheck.Yellow, core.Error{ID: ErrIndexerBehind}},
+		{"database is not available", models.HealthCheck{Round: 1000, Errors: []string{"BOOM"}}, healthcheck.Red, core.Error{ID: ErrIndexerUnavailable}},
+	} {
+		result := Indexer(indexerClient(test.health), algodClient, DefaultIndexerConfig())(time.Second)
+		t.Logf("%v: %+v", test.name, result)
+		if result.Status != test.expected {
+			t.Errorf("%v: status should be %v, but was %v", test.name, test.expected, result.Status)
+		}
+		if test.err != nil && !errors.Is(result.Err, test.err) {
+			t.Errorf("%v: unexpected error: %v", test.name, result.Err)
+		}
+	}
+
+	t.Run("algod is unavailable", func(t *testing.T) {
+		client, err := algod.MakeClient(unavailable(t), token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Indexer(indexerClient(models.HealthCheck{Round: 1000, DbAvailable: true}), client, DefaultIndexerConfig())(time.Second)
+		if result.Status != healthcheck.Yellow || !errors.Is(result.Err, core.Error{ID: ErrAlgodUnavailable}) {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("indexer is unavailable", func(t *testing.T) {
+		client, err := indexer.MakeClient(unavailable(t), token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Indexer(client, algodClient, DefaultIndexerConfig())(time.Second)
+		if result.Status != healthcheck.Red || !errors.Is(result.Err, core.Error{ID: ErrIndexerUnavailable}) {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+}
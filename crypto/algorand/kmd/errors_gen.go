@@ -0,0 +1,134 @@
+// Code generated by errgen from errors.yaml. DO NOT EDIT.
+
+package kmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"log/slog"
+)
+
+var (
+	ErrListWallets           = ulid.MustParse("01HGBTZV8B9RAPX0KPBNZ5JJNR")
+	ErrWalletNotFound        = ulid.MustParse("01M53ZBSX3A68CXN6K2RNGRHAC")
+	ErrDeleteAccounts        = ulid.MustParse("01M53ZS9SMVGBKHPH6NRTP9Q4X")
+	ErrDeleteAccount         = ulid.MustParse("01M53ZS9SMVGBKHPH6NVKV4YW7")
+	ErrWalletUnauthenticated = ulid.MustParse("01M5429QR8Y6TR42P7F2ZH8R5E")
+	ErrUnlockWallet          = ulid.MustParse("01M5429QR8Y6TR42P7F414TN5V")
+)
+
+var errorDefinitions = []core.ErrorDefinition{
+	{
+		ID:          ErrListWallets,
+		Name:        "ErrListWallets",
+		Description: "failed to list the wallets managed by KMD",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/kmd",
+		Category:    core.Unavailable,
+	},
+	{
+		ID:          ErrWalletNotFound,
+		Name:        "ErrWalletNotFound",
+		Description: "wallet does not exist",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/kmd",
+		Category:    core.NotFound,
+	},
+	{
+		ID:          ErrDeleteAccounts,
+		Name:        "ErrDeleteAccounts",
+		Description: "failed to delete one or more wallet accounts - each failed delete is a cause",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/kmd",
+	},
+	{
+		ID:          ErrDeleteAccount,
+		Name:        "ErrDeleteAccount",
+		Description: "failed to delete a wallet account",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/kmd",
+	},
+	{
+		ID:          ErrWalletUnauthenticated,
+		Name:        "ErrWalletUnauthenticated",
+		Description: "wallet password is invalid",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/kmd",
+		Category:    core.Unauthenticated,
+	},
+	{
+		ID:          ErrUnlockWallet,
+		Name:        "ErrUnlockWallet",
+		Description: "failed to unlock the wallet because KMD could not be reached or failed to process the request",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/crypto/algorand/kmd",
+		Category:    core.Unavailable,
+	},
+}
+
+func init() {
+	core.RegisterErrors(errorDefinitions...)
+}
+
+func errListWallets(cause error) core.Error {
+	return core.Error{
+		ID:       ErrListWallets,
+		Name:     "ErrListWallets",
+		Err:      errors.New("failed to list wallets"),
+		Cause:    cause,
+		Category: core.Unavailable,
+	}
+}
+
+func errWalletNotFound(walletName string) core.Error {
+	return core.Error{
+		ID:       ErrWalletNotFound,
+		Name:     "ErrWalletNotFound",
+		Err:      fmt.Errorf("wallet not found: %v", walletName),
+		Category: core.NotFound,
+	}.With(
+		slog.String("wallet", walletName),
+	)
+}
+
+func errDeleteAccounts(walletName string, cause error) core.Error {
+	return core.Error{
+		ID:    ErrDeleteAccounts,
+		Name:  "ErrDeleteAccounts",
+		Err:   fmt.Errorf("failed to delete accounts from wallet: %v", walletName),
+		Cause: cause,
+	}.With(
+		slog.String("wallet", walletName),
+	)
+}
+
+func errDeleteAccount(address string, cause error) core.Error {
+	return core.Error{
+		ID:    ErrDeleteAccount,
+		Name:  "ErrDeleteAccount",
+		Err:   fmt.Errorf("failed to delete account: %v", address),
+		Cause: cause,
+	}.With(
+		slog.String("address", address),
+	)
+}
+
+func errWalletUnauthenticated(walletName string, cause error) core.Error {
+	return core.Error{
+		ID:       ErrWalletUnauthenticated,
+		Name:     "ErrWalletUnauthenticated",
+		Err:      fmt.Errorf("failed to unlock wallet: %v", walletName),
+		Cause:    cause,
+		Category: core.Unauthenticated,
+	}.With(
+		slog.String("wallet", walletName),
+	)
+}
+
+func errUnlockWallet(walletName string, cause error) core.Error {
+	return core.Error{
+		ID:       ErrUnlockWallet,
+		Name:     "ErrUnlockWallet",
+		Err:      fmt.Errorf("failed to unlock wallet: %v", walletName),
+		Cause:    cause,
+		Category: core.Unavailable,
+	}.With(
+		slog.String("wallet", walletName),
+	)
+}
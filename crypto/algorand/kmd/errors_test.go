@@ -0,0 +1,45 @@
+package kmd
+
+import (
+	"errors"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"github.com/oysterpack/oysterpack-smart-go/core/coretest"
+	"net/url"
+	"testing"
+)
+
+func TestErrorDefinitions(t *testing.T) {
+	coretest.CheckErrorDefinitions(t, errorDefinitions...)
+}
+
+func TestInitWalletHandleError(t *testing.T) {
+	t.Run("KMD rejected the password", func(t *testing.T) {
+		err := initWalletHandleError("test", errors.New("wrong password"))
+		if !errors.Is(err, core.Error{ID: ErrWalletUnauthenticated}) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if core.CategoryOf(err) != core.Unauthenticated || core.IsRetryable(err) {
+			t.Errorf("error should be classified as Unauthenticated: %v", core.CategoryOf(err))
+		}
+	})
+
+	t.Run("KMD failed", func(t *testing.T) {
+		err := initWalletHandleError("test", errors.New("database error"))
+		if !errors.Is(err, core.Error{ID: ErrUnlockWallet}) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !core.IsRetryable(err) {
+			t.Error("error should be retryable")
+		}
+	})
+
+	t.Run("KMD could not be reached", func(t *testing.T) {
+		err := initWalletHandleError("test", &url.Error{Op: "Post", URL: "http://localhost:4002", Err: errors.New("connection refused")})
+		if !errors.Is(err, core.Error{ID: ErrUnlockWallet}) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !core.IsRetryable(err) {
+			t.Error("error should be retryable")
+		}
+	})
+}
@@ -2,13 +2,11 @@ package kmd
 
 import (
 	"errors"
-	"fmt"
 	"github.com/algorand/go-algorand-sdk/v2/client/kmd"
 	"github.com/algorand/go-algorand-sdk/v2/mnemonic"
 	"github.com/algorand/go-algorand-sdk/v2/types"
-	"github.com/oklog/ulid/v2"
-	"github.com/oysterpack/oysterpack-smart-go/core"
 	"log/slog"
+	"net/url"
 	"strings"
 	"sync"
 )
@@ -28,28 +26,6 @@ type ListWallets func() ([]Wallet, error)
 // If the wallet is not found, then a
 type GetWallet func(name string) (Wallet, error)
 
-var (
-	ErrListWallets    = ulid.MustParse("01HGBTZV8B9RAPX0KPBNZ5JJNR")
-	ErrWalletNotFound = ulid.MustParse("01HGBTZV8B9RAPX0KPBNZ5JJNR")
-)
-
-func errListWallets(cause error) core.Error {
-	return core.Error{
-		ID:    ErrListWallets,
-		Name:  "ErrListWallets",
-		Err:   errors.New("failed to list wallets"),
-		Cause: cause,
-	}
-}
-
-func errWalletNotFound(walletName string) core.Error {
-	return core.Error{
-		ID:   ErrWalletNotFound,
-		Name: "ErrWalletNotFound",
-		Err:  errors.New(fmt.Sprintf("wallet not found: %s", walletName)),
-	}
-}
-
 func ProvideListWallets(kmdClient *kmd.Client) ListWallets {
 	return func() ([]Wallet, error) {
 		kmdWallets, err := kmdClient.ListWallets()
@@ -127,10 +103,9 @@ type WalletManager interface {
 	//
 	// - At least 1 account address to delete must be specified.
 	//
-	// Any errors that occur while deleting an account are returned in the map.
-	// If there is no entry for the address in the returned map, then it means the account was successfully deleted.
-	// If all deletes completed successfully, then nil will be returned.
-	DeleteAccounts(name, password string, addresses ...string) (deleteErrors map[string]error, err error)
+	// If any deletes fail, then an ErrDeleteAccounts error is returned, whose causes are the ErrDeleteAccount errors
+	// for each account that failed to be deleted. Use core.Find or errors.As to inspect them.
+	DeleteAccounts(name, password string, addresses ...string) error
 
 	// ExportPrivateKey exports the account's private key in [mnemonic] form
 	//
@@ -156,7 +131,7 @@ func New(kmdClient kmd.Client) WalletManager {
 func (walletManager *kmdWalletManager) List() ([]Wallet, error) {
 	kmdWallets, err := walletManager.kmdClient.ListWallets()
 	if err != nil {
-		return nil, err
+		return nil, errListWallets(err)
 	}
 	wallets := make([]Wallet, len(kmdWallets.Wallets))
 	for i, wallet := range kmdWallets.Wallets {
@@ -169,7 +144,7 @@ func (walletManager *kmdWalletManager) List() ([]Wallet, error) {
 func (walletManager *kmdWalletManager) Contains(name string) (bool, error) {
 	kmdWallets, err := walletManager.kmdClient.ListWallets()
 	if err != nil {
-		return false, err
+		return false, errListWallets(err)
 	}
 	for _, wallet := range kmdWallets.Wallets {
 		if wallet.Name == name {
@@ -231,7 +206,7 @@ func (walletManager *kmdWalletManager) Get(name string) (Wallet, error) {
 		}
 	}
 
-	return Wallet{}, errors.New("wallet does not exist")
+	return Wallet{}, errWalletNotFound(name)
 }
 
 func (walletManager *kmdWalletManager) walletHandle(name, password string) (handle string, err error) {
@@ -242,11 +217,24 @@ func (walletManager *kmdWalletManager) walletHandle(name, password string) (hand
 
 	response, err := walletManager.kmdClient.InitWalletHandle(wallet.Id, password)
 	if err != nil {
-		return "", err
+		return "", initWalletHandleError(name, err)
 	}
 	return response.WalletHandleToken, nil
 }
 
+// initWalletHandleError classifies a failure to init a wallet handle.
+//
+// The KMD client does not expose the HTTP status code, i.e., KMD errors are only described by their message. Thus, an
+// error is only treated as a password rejection if KMD says so. Any other failure, e.g., a transport error or a KMD
+// internal error, is treated as transient.
+func initWalletHandleError(name string, err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) && strings.Contains(strings.ToLower(err.Error()), "password") {
+		return errWalletUnauthenticated(name, err)
+	}
+	return errUnlockWallet(name, err)
+}
+
 func (walletManager *kmdWalletManager) ExportBackupPhrase(name, password string) (backupPhrase string, err error) {
 	name, password, err = trimNamePassword(name, password)
 	if err != nil {
@@ -302,16 +290,10 @@ func (walletManager *kmdWalletManager) ListAccounts(name, password string) (addr
 		return nil, err
 	}
 
-	wallet, err := walletManager.Get(name)
-	if err != nil {
-		return nil, err
-	}
-
-	walletHandleResponse, err := walletManager.kmdClient.InitWalletHandle(wallet.Id, password)
+	walletHandle, err := walletManager.walletHandle(name, password)
 	if err != nil {
 		return nil, err
 	}
-	walletHandle := walletHandleResponse.WalletHandleToken
 	defer walletManager.releaseWalletHandle(name, walletHandle)
 
 	listKeysResponse, err := walletManager.kmdClient.ListKeys(walletHandle)
@@ -396,45 +378,41 @@ func (walletManager *kmdWalletManager) DeleteAccount(name, password, address str
 	return err
 }
 
-func (walletManager *kmdWalletManager) DeleteAccounts(name, password string, addresses ...string) (deleteErrors map[string]error, err error) {
+func (walletManager *kmdWalletManager) DeleteAccounts(name, password string, addresses ...string) error {
 	if len(addresses) == 0 {
-		return nil, errors.New("at least 1 account to delete must be specified")
+		return errors.New("at least 1 account to delete must be specified")
 	}
 
-	name, password, err = trimNamePassword(name, password)
+	name, password, err := trimNamePassword(name, password)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	walletHandle, err := walletManager.walletHandle(name, password)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer walletManager.releaseWalletHandle(name, walletHandle)
 
-	deleteErrors = make(map[string]error)
-	lock := sync.Mutex{}
+	deleteErrors := make([]error, len(addresses))
 	wg := sync.WaitGroup{}
 	wg.Add(len(addresses))
 
-	for _, address := range addresses {
-		go func(address string) {
+	for i, address := range addresses {
+		go func(i int, address string) {
 			defer wg.Done()
-			_, err = walletManager.kmdClient.DeleteKey(walletHandle, password, address)
-			if err != nil {
-				lock.Lock()
-				deleteErrors[address] = err
-				lock.Unlock()
+			if _, err := walletManager.kmdClient.DeleteKey(walletHandle, password, address); err != nil {
+				deleteErrors[i] = errDeleteAccount(address, err)
 			}
-		}(address)
+		}(i, address)
 	}
 
 	wg.Wait()
 
-	if len(deleteErrors) == 0 {
-		return nil, nil
+	if err := errors.Join(deleteErrors...); err != nil {
+		return errDeleteAccounts(name, err)
 	}
-	return
+	return nil
 }
 
 func (walletManager *kmdWalletManager) ExportPrivateKey(name, password, address string) (passPhrase string, err error) {
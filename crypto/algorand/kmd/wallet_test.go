@@ -1,8 +1,10 @@
 package kmd_test
 
 import (
+	"errors"
 	"github.com/algorand/go-algorand-sdk/v2/crypto"
 	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
 	"github.com/oysterpack/oysterpack-smart-go/crypto/algorand/kmd"
 	"github.com/oysterpack/oysterpack-smart-go/crypto/algorand/test/localnet"
 	"sort"
@@ -214,8 +216,8 @@ func TestExportBackupPhrase(t *testing.T) {
 			t.Error("Test should have failed because the wallet does not exist")
 		}
 		t.Log(err)
-		if err.Error() != "wallet does not exist" {
-			t.Error("invalid error message")
+		if !errors.Is(err, core.Error{ID: kmd.ErrWalletNotFound}) {
+			t.Error("error should be ErrWalletNotFound")
 		}
 	})
 
@@ -230,6 +232,12 @@ func TestExportBackupPhrase(t *testing.T) {
 		if !strings.Contains(err.Error(), "wrong password") {
 			t.Error("invalid error message")
 		}
+		if !errors.Is(err, core.Error{ID: kmd.ErrWalletUnauthenticated}) {
+			t.Error("error should be ErrWalletUnauthenticated")
+		}
+		if core.IsRetryable(err) {
+			t.Error("invalid password should not be retryable")
+		}
 	})
 }
 
@@ -414,14 +422,11 @@ func TestDeleteAccounts(t *testing.T) {
 			}
 			accounts[i] = address
 		}
-		deleteErrors, err := walletManager.DeleteAccounts(name, password, accounts...)
+		err := walletManager.DeleteAccounts(name, password, accounts...)
 		if err != nil {
 			t.Error("Failed to delete accounts", err)
 			return
 		}
-		if len(deleteErrors) > 0 {
-			t.Error("There should no errors")
-		}
 		for _, account := range accounts {
 			exists, err := walletManager.ContainsAccount(name, password, account)
 			if err != nil {
@@ -444,14 +449,11 @@ func TestDeleteAccounts(t *testing.T) {
 		}
 		accounts[0] = address                                   // existent account
 		accounts[1] = crypto.GenerateAccount().Address.String() // non-existent account
-		deleteErrors, err := walletManager.DeleteAccounts(name, password, accounts...)
+		err = walletManager.DeleteAccounts(name, password, accounts...)
 		if err != nil {
 			t.Error("Failed to delete accounts", err)
 			return
 		}
-		if len(deleteErrors) > 0 {
-			t.Error("There should no errors")
-		}
 		for _, account := range accounts {
 			exists, err := walletManager.ContainsAccount(name, password, account)
 			if err != nil {
@@ -472,14 +474,11 @@ func TestDeleteAccounts(t *testing.T) {
 			accounts[i] = crypto.GenerateAccount().Address.String()
 		}
 
-		deleteErrors, err := walletManager.DeleteAccounts(name, password, accounts...)
+		err := walletManager.DeleteAccounts(name, password, accounts...)
 		if err != nil {
 			t.Error("Failed to delete accounts", err)
 			return
 		}
-		if len(deleteErrors) > 0 {
-			t.Error("There should no errors")
-		}
 	})
 }
 
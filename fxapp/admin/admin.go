@@ -0,0 +1,191 @@
+// Package admin serves operational endpoints on a separate admin port as an opt-in [Fx] module.
+//
+// [Fx] = https://uber-go.github.io/fx/
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/config"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/logging"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+	"time"
+)
+
+// ConfigPath is the config section path for the admin server Config
+const ConfigPath = "admin"
+
+// HealthHandlerName is the name of the health endpoints http.Handler, which is provided by the fxhealthcheck module
+const HealthHandlerName = "healthcheck"
+
+// Config is the admin server config
+type Config struct {
+	// Addr is the TCP address the admin server listens on. It defaults to localhost because the endpoints are not
+	// meant to be exposed publicly.
+	Addr string `default:"localhost:9090" validate:"required"`
+	// ReadHeaderTimeout is the amount of time allowed to read request headers
+	ReadHeaderTimeout time.Duration `default:"5s" validate:"min=1ms"`
+}
+
+// Module serves the admin endpoints while the app is running:
+//   - /debug/pprof/ - runtime profiling data - see net/http/pprof
+//   - /healthz, /livez, /readyz, /startupz - health endpoints, if the fxhealthcheck module is used
+//   - /info - app info and build info - see fxapp.AppInfo
+//   - /loglevel - gets and sets the log level at runtime, if the logging module is used - see logging.Module
+//   - /graph - app dependency graph as DOT, or as JSON via ?format=json - see fxapp.Graph
+//   - /lifecycle - constructor and lifecycle hook timings - see fxapp.Timings
+//
+// The Config is loaded from the config section at [ConfigPath] via the config.Loader that must be provided by the app.
+//
+// The server starts listening when the app starts, which fails if the address cannot be bound. The server is shut down
+// gracefully when the app stops.
+var Module = fx.Module("admin",
+	config.Section[Config](ConfigPath),
+	fx.Provide(
+		fx.Annotate(
+			newServer,
+			fx.ParamTags(
+				``, ``, ``, ``,
+				`name:"`+fxapp.InfoHandlerName+`"`,
+				`name:"`+fxapp.GraphHandlerName+`"`,
+				`name:"`+HealthHandlerName+`" optional:"true"`,
+				`name:"`+logging.LevelHandlerName+`" optional:"true"`,
+			),
+		),
+	),
+	fx.Invoke(func(*Server) {}),
+)
+
+// Server is the admin HTTP server
+type Server struct {
+	config  Config
+	handler http.Handler
+	logger  *zap.Logger
+
+	lock     sync.RWMutex
+	server   *http.Server
+	listener net.Listener
+	done     chan struct{}
+}
+
+// Addr returns the address the server is listening on, which is nil if the server is not running
+func (s *Server) Addr() net.Addr {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Start starts listening, and serves requests in the background.
+//
+// A new http.Server is used each time the server is started, because an http.Server cannot be reused once it has been
+// shut down. Starting a server that is already running is a no-op.
+func (s *Server) Start(context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.server != nil {
+		return nil
+	}
+	listener, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Handler:           s.handler,
+		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
+		ErrorLog:          zap.NewStdLog(s.logger),
+	}
+	done := make(chan struct{})
+	s.server = server
+	s.listener = listener
+	s.done = done
+
+	go func() {
+		defer close(done)
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("admin server failed", zap.Error(err))
+		}
+	}()
+	s.logger.Info("admin server started", zap.Stringer("addr", listener.Addr()))
+	return nil
+}
+
+// Stop gracefully shuts down the server, waiting for active requests to complete until the context is done
+func (s *Server) Stop(ctx context.Context) error {
+	s.lock.Lock()
+	server := s.server
+	done := s.done
+	s.server = nil
+	s.listener = nil
+	s.done = nil
+	s.lock.Unlock()
+	if server == nil {
+		return nil
+	}
+	err := server.Shutdown(ctx)
+	<-done
+	s.logger.Info("admin server stopped")
+	return err
+}
+
+// newServer params are annotated in Module: the health and log level handlers are optional, i.e., they may be nil
+func newServer(
+	lifecycle fx.Lifecycle,
+	logger *zap.Logger,
+	config Config,
+	timings *fxapp.Timings,
+	info, graph, health, logLevel http.Handler,
+) *Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	if health != nil {
+		for _, path := range []string{"/healthz", "/livez", "/readyz", "/startupz"} {
+			mux.Handle(path, health)
+		}
+	}
+	mux.Handle("/info", info)
+	if logLevel != nil {
+		mux.Handle("/loglevel", logLevel)
+	}
+	mux.Handle("/graph", graph)
+	mux.Handle("/lifecycle", newLifecycleHandler(timings))
+
+	server := &Server{
+		config:  config,
+		handler: mux,
+		logger:  logger.Named("admin"),
+	}
+	lifecycle.Append(fx.StartStopHook(server.Start, server.Stop))
+	return server
+}
+
+// lifecycle is the /lifecycle response
+type lifecycle struct {
+	Constructors []fxapp.Timing `json:"constructors"`
+	OnStart      []fxapp.Timing `json:"on_start"`
+	OnStop       []fxapp.Timing `json:"on_stop"`
+}
+
+func newLifecycleHandler(timings *fxapp.Timings) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(lifecycle{
+			Constructors: timings.Constructors(),
+			OnStart:      timings.OnStart(),
+			OnStop:       timings.OnStop(),
+		})
+	})
+}
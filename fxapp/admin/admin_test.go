@@ -0,0 +1,173 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/config"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/logging"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func noEnv(string) (string, bool) {
+	return "", false
+}
+
+func newHealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "Green "+r.URL.Path)
+	})
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	response, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response.StatusCode, string(body)
+}
+
+func TestModule(t *testing.T) {
+	var server *Server
+	app := fxapp.New(
+		fx.Supply(config.Loader{
+			LookupEnv: noEnv,
+			Args:      []string{"--admin.addr=127.0.0.1:0", "--log.output_paths=stdout"},
+		}),
+		logging.Module,
+		Module,
+		fx.Provide(fx.Annotate(newHealthHandler, fx.ResultTags(`name:"`+HealthHandlerName+`"`))),
+		fx.Populate(&server),
+	)
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	baseURL := "http://" + server.Addr().String()
+
+	for _, test := range []struct {
+		path     string
+		contains string
+	}{
+		{"/debug/pprof/", "goroutine"},
+		{"/debug/pprof/cmdline", "admin.test"},
+		{"/healthz", "Green /healthz"},
+		{"/readyz", "Green /readyz"},
+		{"/info", `"instance_id"`},
+		{"/loglevel", `"level":"info"`},
+		{"/graph", "digraph"},
+		{"/graph?format=json", `"constructors"`},
+	} {
+		t.Run(test.path, func(t *testing.T) {
+			code, body := get(t, baseURL+test.path)
+			if code != http.StatusOK || !strings.Contains(body, test.contains) {
+				t.Errorf("unexpected response: %v: %v", code, body)
+			}
+		})
+	}
+
+	t.Run("change log level", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPut, baseURL+"/loglevel", strings.NewReader(`{"level":"debug"}`))
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = response.Body.Close()
+		if _, body := get(t, baseURL+"/loglevel"); !strings.Contains(body, `"level":"debug"`) {
+			t.Errorf("log level was not changed: %v", body)
+		}
+	})
+
+	t.Run("/lifecycle", func(t *testing.T) {
+		code, body := get(t, baseURL+"/lifecycle")
+		var view lifecycle
+		if err := json.Unmarshal([]byte(body), &view); err != nil {
+			t.Fatal(code, err)
+		}
+		if len(view.Constructors) == 0 || len(view.OnStart) == 0 {
+			t.Errorf("lifecycle timings should be reported: %v", body)
+		}
+	})
+
+	addr := server.Addr().String()
+	if err := app.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if server.Addr() != nil {
+		t.Error("server should not be listening")
+	}
+	if _, err := http.Get("http://" + addr + "/info"); err == nil {
+		t.Error("server should be shut down")
+	}
+}
+
+func TestModule_Optional(t *testing.T) {
+	var server *Server
+	app := fxapp.New(
+		fx.Supply(zap.NewNop(), config.Loader{LookupEnv: noEnv, Args: []string{"--admin.addr=127.0.0.1:0"}}),
+		Module,
+		fx.Populate(&server),
+	)
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer app.Stop(context.Background())
+
+	baseURL := "http://" + server.Addr().String()
+	for _, path := range []string{"/healthz", "/loglevel"} {
+		if code, _ := get(t, baseURL+path); code != http.StatusNotFound {
+			t.Errorf("%v should not be served: %v", path, code)
+		}
+	}
+}
+
+func TestModule_AddrInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	app := fxapp.New(
+		fx.Supply(zap.NewNop(), config.Loader{LookupEnv: noEnv, Args: []string{"--admin.addr=" + listener.Addr().String()}}),
+		Module,
+	)
+	if err := app.Start(context.Background()); err == nil {
+		_ = app.Stop(context.Background())
+		t.Error("app should fail to start when the admin address is in use")
+	}
+}
+
+func TestServer_Restart(t *testing.T) {
+	var server *Server
+	app := fxapp.New(
+		fx.Supply(zap.NewNop(), config.Loader{LookupEnv: noEnv, Args: []string{"--admin.addr=127.0.0.1:0"}}),
+		Module,
+		fx.Populate(&server),
+	)
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer app.Stop(context.Background())
+
+	if err := server.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if code, body := get(t, "http://"+server.Addr().String()+"/info"); code != http.StatusOK || !strings.Contains(body, `"instance_id"`) {
+		t.Errorf("restarted server should serve requests: %v: %v", code, body)
+	}
+}
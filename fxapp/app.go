@@ -5,9 +5,13 @@ package fxapp
 
 import (
 	"context"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/logging"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
 	"go.uber.org/zap"
+	"io"
+	"log"
+	"log/slog"
 )
 
 // New constructs a new Fx app instance.
@@ -19,21 +23,64 @@ import (
 // - Same logger will be used for logging Fx events
 // - Shutdown hook is registered to flush the logger
 // - Output from the standard library's package-global logger is redirected to the supplied logger at InfoLevel
+// - AppInfo is provided, and is added as the "app" field to the logger built by logging.Module, i.e., the logger that
+//   is injected into the app's components - see Identity. If the app provides its own logger, then the "app" field is
+//   only added to the loggers that are configured here, i.e., the Fx event logger, the standard library logger, the
+//   global zap loggers, and the log/slog default logger. Either way, the provided logger is not decorated, which leaves
+//   the application free to decorate it.
 // - Global zap loggers are replaced with the provided logger
+// - When the app starts, the log/slog default logger is replaced with a logger that writes to the provided logger.
+//   The original default logger is restored when the app stops.
+//
+// Introspection is provided via:
+// - http.Handler named InfoHandlerName, which reports the AppInfo as JSON
+// - http.Handler named GraphHandlerName, which reports the app dependency graph as DOT or JSON - see Graph
+// - *Timings, which records how long each constructor and lifecycle hook took to run. Startup and shutdown reports
+//   are logged once the app has started and stopped.
 //
 // NOTE: The reason the logger is not explicitly specified as a param is to allow the logger to be constructed using
 // configuration and resources that ore provided by the application.
 
 // CIa8rnHML0zaz7j
 func New(options ...fx.Option) *fx.App {
-	return fx.New(
-		fx.WithLogger(func(log *zap.Logger) fxevent.Logger {
-			return &fxevent.ZapLogger{Logger: log}
+	return fx.New(Options(options...))
+}
+
+// Options returns the options that New constructs the app with, i.e., the fxapp options followed by the specified
+// options. It enables the app graph to be checked without constructing the app, e.g., via fx.ValidateApp.
+func Options(options ...fx.Option) fx.Option {
+	timings := &Timings{}
+	return fx.Options(
+		fx.WithLogger(func(params loggerParams) fxevent.Logger {
+			log := decorateLogger(params)
+			return &timingLogger{
+				Logger:  &fxevent.ZapLogger{Logger: log},
+				log:     log,
+				timings: timings,
+			}
 		}),
-		fx.Invoke(
-			registerLoggerShutdownHook,
-			zap.RedirectStdLog,
-			zap.ReplaceGlobals,
+		fx.Supply(timings),
+		logging.ProvideFields(logField),
+		fx.Provide(
+			newAppInfo,
+			fx.Annotate(
+				newInfoHandler,
+				fx.ResultTags(`name:"`+InfoHandlerName+`"`),
+			),
+			fx.Annotate(
+				newGraphHandler,
+				fx.ResultTags(`name:"`+GraphHandlerName+`"`),
+			),
+		),
+		// the logger is decorated within a private module, which keeps the root decoration available to the app
+		fx.Module("fxapp",
+			fx.Decorate(decorateLogger),
+			fx.Invoke(
+				registerLoggerShutdownHook,
+				registerSlogDefault,
+				zap.RedirectStdLog,
+				zap.ReplaceGlobals,
+			),
 		),
 		fx.Options(options...),
 	)
@@ -47,3 +94,27 @@ func registerLoggerShutdownHook(lc fx.Lifecycle, log *zap.Logger) {
 		},
 	})
 }
+
+func registerSlogDefault(lc fx.Lifecycle, logger *zap.Logger) {
+	var (
+		previous  *slog.Logger
+		logOutput io.Writer
+		logFlags  int
+	)
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			previous = slog.Default()
+			// slog.SetDefault also redirects the standard library's logger, which is not undone when the original
+			// default logger is restored
+			logOutput, logFlags = log.Writer(), log.Flags()
+			slog.SetDefault(slog.New(logging.NewSlogHandler(logger)))
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			slog.SetDefault(previous)
+			log.SetOutput(logOutput)
+			log.SetFlags(logFlags)
+			return nil
+		},
+	})
+}
@@ -2,9 +2,17 @@ package fxapp_test
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/oysterpack/oysterpack-smart-go/fxapp"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/config"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/logging"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -112,3 +120,76 @@ func TestNew(t *testing.T) {
 	})
 
 }
+
+func TestNew_SlogDefault(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	defaultLogger := slog.Default()
+	app := fxapp.New(
+		fx.Supply(zap.New(core)),
+	)
+	startApp(t, app)
+	if slog.Default() == defaultLogger {
+		t.Fatal("slog default logger should have been replaced")
+	}
+	slog.Warn("Failed to release KMD handle", slog.Group("wallet", "name", "foo"))
+	slog.Debug("dropped")
+	stdlog.Println("CIAO MUNDO!!")
+	stopApp(t, app)
+
+	entries := logs.FilterMessage("Failed to release KMD handle").All()
+	if len(entries) != 1 || entries[0].Level != zapcore.WarnLevel {
+		t.Fatalf("slog output should be logged by the app logger: %v", entries)
+	}
+	if wallet := entries[0].ContextMap()["wallet"].(map[string]any); wallet["name"] != "foo" {
+		t.Errorf("slog group should be logged: %v", wallet)
+	}
+	if logs.FilterMessage("dropped").Len() != 0 {
+		t.Error("slog output should be filtered by the app logger level")
+	}
+	if logs.FilterMessage("CIAO MUNDO!!").Len() != 1 {
+		t.Error("standard library log output should be logged by the app logger")
+	}
+
+	if slog.Default() != defaultLogger {
+		t.Error("slog default logger should have been restored")
+	}
+}
+
+func TestNew_AppDecoratesLogger(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	app := fxapp.New(
+		fx.Supply(config.Loader{LookupEnv: func(string) (string, bool) { return "", false }, Args: []string{"--log.output_paths=" + logFile}}),
+		logging.Module,
+		fx.Decorate(func(logger *zap.Logger) *zap.Logger {
+			return logger.With(zap.String("component", "test"))
+		}),
+		fx.Invoke(func(logger *zap.Logger) {
+			logger.Info("running")
+		}),
+	)
+	startApp(t, app)
+	zap.L().Info("global")
+	stopApp(t, app)
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := make(map[string]map[string]any)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Count(line, `"app":`) != 1 {
+			t.Errorf("app field should be logged once: %v", line)
+		}
+		entries[entry["msg"].(string)] = entry
+	}
+	if entry := entries["running"]; entry["component"] != "test" || entry["app"] == nil {
+		t.Errorf("injected logger should be the decorated app logger with the app field: %v", entry)
+	}
+	if entry := entries["global"]; entry["component"] != "test" || entry["app"] == nil {
+		t.Errorf("global logger should be the decorated app logger with the app field: %v", entry)
+	}
+}
@@ -0,0 +1,264 @@
+// Package config loads typed configuration structs from layered sources. Each source overrides the previous ones:
+//  1. defaults, declared via `default` struct tags
+//  2. YAML (.yaml, .yml) or TOML (.toml) files, applied in the order they are specified
+//  3. environment variables
+//  4. command line flags
+//
+// Config field keys default to the field name in snake_case, e.g., OutputPaths -> output_paths, and can be overridden
+// via the `config` struct tag. Nested structs form a dotted field path, e.g., log.output_paths, which maps to:
+//   - file keys: nested tables / mappings
+//   - environment variables: the prefixed path in upper snake case, e.g., APP_LOG_OUTPUT_PATHS. The variable name can be
+//     overridden via the `env` struct tag.
+//   - flags: the path, e.g., --log.output_paths=stdout
+//
+// Slices are specified as lists in files, and as comma separated values in environment variables and flags.
+// Fields whose type implements encoding.TextUnmarshaler, e.g., zapcore.Level, are decoded from text.
+//
+// Once loaded, configs are validated via `validate` struct tags - see Validate.
+//
+// Errors report the field path and the source that supplied the value - see FieldError.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"go.uber.org/fx"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Loader loads config structs
+type Loader struct {
+	// Files are applied in order, i.e., later files override earlier files.
+	// The file format is determined by its extension: .yaml, .yml or .toml
+	Files []string
+	// EnvPrefix is prepended to environment variable names, e.g., APP -> APP_LOG_LEVEL
+	EnvPrefix string
+	// Args are the command line args, e.g., os.Args[1:]. Only the flags for config fields are applied. Other args, e.g.,
+	// positional args, are left alone. Boolean flags only take a value via --path=value, e.g., --server.tls=false.
+	Args []string
+	// LookupEnv looks up environment variables. If nil, then os.LookupEnv is used.
+	LookupEnv func(key string) (string, bool)
+}
+
+// Load loads the config into the struct that v points to
+func (l Loader) Load(v any) error {
+	return l.LoadSection("", v)
+}
+
+// LoadSection loads the config section at the specified dotted path, e.g., "log", into the struct that v points to.
+//
+// Only the section's subtree is loaded from files, and only environment variables and flags for the section's fields
+// are applied.
+func (l Loader) LoadSection(path string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a non-nil pointer to a struct: %T", v)
+	}
+	s := &loader{sources: make(map[string]Source)}
+	walk(rv.Elem(), path, s.applyDefault)
+	for _, file := range l.Files {
+		tree, err := readFile(file)
+		if err != nil {
+			return err
+		}
+		if subtree, ok := lookupTree(tree, path); ok {
+			s.applyTree(rv.Elem(), path, subtree, Source{Kind: File, Name: file})
+		}
+	}
+	lookupEnv := l.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	walk(rv.Elem(), path, func(field reflect.Value, sf reflect.StructField, path string) {
+		name := envName(l.EnvPrefix, sf, path)
+		if value, ok := lookupEnv(name); ok {
+			s.set(field, path, value, Source{Kind: Env, Name: name})
+		}
+	})
+	s.applyFlags(rv.Elem(), path, l.Args)
+	if len(s.errs) > 0 {
+		return errors.Join(s.errs...)
+	}
+	return validate(rv.Elem(), path, s.sources)
+}
+
+// Section provides the config section at the specified dotted path into the fx graph as a T, which must be a struct.
+//
+// The config is loaded via the Loader, which must be provided by the app, e.g., fx.Supply(config.Loader{...}).
+func Section[T any](path string) fx.Option {
+	return fx.Provide(func(loader Loader) (T, error) {
+		var section T
+		err := loader.LoadSection(path, &section)
+		return section, err
+	})
+}
+
+// loader tracks the source of each field value and collects errors while loading a config
+type loader struct {
+	sources map[string]Source
+	errs    []error
+}
+
+func (s *loader) set(field reflect.Value, path string, value any, source Source) {
+	if err := setValue(field, value); err != nil {
+		s.errs = append(s.errs, &FieldError{Path: path, Source: source, Err: err})
+		return
+	}
+	s.sources[path] = source
+}
+
+func (s *loader) applyDefault(field reflect.Value, sf reflect.StructField, path string) {
+	if value, ok := sf.Tag.Lookup("default"); ok {
+		s.set(field, path, value, Source{Kind: Default})
+	}
+}
+
+// walk calls fn for each leaf field, i.e., descending into nested structs
+func walk(v reflect.Value, path string, fn func(field reflect.Value, sf reflect.StructField, path string)) {
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key, ok := fieldKey(sf)
+		if !ok {
+			continue
+		}
+		fieldPath := joinPath(path, key)
+		if isNested(sf.Type) {
+			walk(v.Field(i), fieldPath, fn)
+			continue
+		}
+		fn(v.Field(i), sf, fieldPath)
+	}
+}
+
+func (s *loader) applyTree(v reflect.Value, path string, tree map[string]any, source Source) {
+	fields := fieldsByKey(v)
+	for key, value := range tree {
+		fieldPath := joinPath(path, key)
+		field, ok := fields[key]
+		if !ok {
+			s.errs = append(s.errs, &FieldError{Path: fieldPath, Source: source, Err: errors.New("unknown config field")})
+			continue
+		}
+		if isNested(field.Type()) {
+			subtree, ok := value.(map[string]any)
+			if !ok {
+				s.errs = append(s.errs, &FieldError{Path: fieldPath, Source: source, Err: fmt.Errorf("expected a table, but was: %T", value)})
+				continue
+			}
+			s.applyTree(field, fieldPath, subtree, source)
+			continue
+		}
+		s.set(field, fieldPath, value, source)
+	}
+}
+
+// applyFlags applies the flags for the config fields.
+//
+// Flags for unknown fields are reported only if they are in a config table's namespace, e.g., --log.bogus, in order to
+// detect misspelled fields. Other flags are ignored, i.e., flags for other config sections and flags that are not
+// config flags.
+func (s *loader) applyFlags(v reflect.Value, path string, args []string) {
+	fields := make(map[string]bool)
+	tables := make(map[string]bool)
+	walk(v, path, func(field reflect.Value, sf reflect.StructField, fieldPath string) {
+		fields[fieldPath] = sf.Type.Kind() == reflect.Bool || (sf.Type.Kind() == reflect.Pointer && sf.Type.Elem().Kind() == reflect.Bool)
+		for table, _, ok := cutLast(fieldPath); ok && len(table) >= len(path); table, _, ok = cutLast(table) {
+			tables[table] = true
+		}
+	})
+	flags, err := parseFlags(args, fields)
+	if err != nil {
+		s.errs = append(s.errs, err)
+		return
+	}
+	walk(v, path, func(field reflect.Value, sf reflect.StructField, path string) {
+		if value, ok := flags[path]; ok {
+			s.set(field, path, value, Source{Kind: Flag, Name: "--" + path})
+		}
+	})
+	for flag := range flags {
+		if _, known := fields[flag]; known {
+			continue
+		}
+		if table, _, ok := cutLast(flag); ok && tables[table] {
+			s.errs = append(s.errs, &FieldError{Path: flag, Source: Source{Kind: Flag, Name: "--" + flag}, Err: errors.New("unknown config field")})
+		}
+	}
+}
+
+// cutLast cuts the dotted path around its last key, e.g., log.level -> log, level
+func cutLast(path string) (before, after string, found bool) {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i], path[i+1:], true
+	}
+	return "", path, false
+}
+
+func fieldKey(sf reflect.StructField) (string, bool) {
+	key := sf.Tag.Get("config")
+	if key == "-" {
+		return "", false
+	}
+	if key == "" {
+		key = snakeCase(sf.Name)
+	}
+	return key, true
+}
+
+func fieldsByKey(v reflect.Value) map[string]reflect.Value {
+	fields := make(map[string]reflect.Value, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if key, ok := fieldKey(sf); ok {
+			fields[key] = v.Field(i)
+		}
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func envName(prefix string, sf reflect.StructField, path string) string {
+	if name := sf.Tag.Get("env"); name != "" {
+		return name
+	}
+	name := strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+// snakeCase converts a Go field name to snake case, e.g., OutputPaths -> output_paths, HTTPAddr -> http_addr
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		upper := r >= 'A' && r <= 'Z'
+		if upper && i > 0 {
+			prevLower := runes[i-1] < 'A' || runes[i-1] > 'Z'
+			nextLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+		}
+		if upper {
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
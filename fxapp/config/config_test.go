@@ -0,0 +1,299 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/fx"
+	"go.uber.org/zap/zapcore"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type logConfig struct {
+	Level       zapcore.Level `default:"info"`
+	Encoding    string        `default:"json" validate:"oneof=json console"`
+	OutputPaths []string      `default:"stdout" validate:"min=1"`
+	Fields      map[string]string
+}
+
+type serverConfig struct {
+	HTTPAddr     string        `default:":8080" validate:"required"`
+	ReadTimeout  time.Duration `default:"5s" validate:"min=1s"`
+	MaxConns     int           `config:"max_connections" env:"MAX_CONNS" validate:"min=1,max=1000"`
+	TLS          bool
+	internal     string
+	Ignored      string `config:"-"`
+	OptionalPort *uint16
+}
+
+type appConfig struct {
+	Name   string `validate:"required"`
+	Log    logConfig
+	Server serverConfig
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	}
+}
+
+func TestLoader_Load(t *testing.T) {
+	yamlFile := writeFile(t, "app.yaml", `
+name: foo
+log:
+  level: debug
+  output_paths: [stdout, /var/log/app.log]
+  fields:
+    region: us-east-1
+server:
+  max_connections: 100
+  read_timeout: 10s
+`)
+	tomlFile := writeFile(t, "app.toml", `
+[log]
+encoding = "console"
+
+[server]
+http_addr = ":9090"
+max_connections = 200
+optional_port = 8443
+`)
+	loader := Loader{
+		Files:     []string{yamlFile, tomlFile},
+		EnvPrefix: "APP",
+		LookupEnv: env(map[string]string{
+			"APP_SERVER_HTTP_ADDR": ":7070",
+			"MAX_CONNS":            "300",
+		}),
+		Args: []string{"--server.http_addr=:6060", "--server.tls", "--log.fields", "region=eu-west-1,zone=a"},
+	}
+
+	var config appConfig
+	if err := loader.Load(&config); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("%+v", config)
+	for _, check := range []struct {
+		name     string
+		ok       bool
+		expected any
+	}{
+		{"name from yaml", config.Name == "foo", "foo"},
+		{"level from yaml", config.Log.Level == zapcore.DebugLevel, zapcore.DebugLevel},
+		{"encoding from toml overrides default", config.Log.Encoding == "console", "console"},
+		{"output paths from yaml", strings.Join(config.Log.OutputPaths, ",") == "stdout,/var/log/app.log", "stdout,/var/log/app.log"},
+		{"fields from flag", config.Log.Fields["region"] == "eu-west-1" && config.Log.Fields["zone"] == "a", "region=eu-west-1,zone=a"},
+		{"read timeout from yaml", config.Server.ReadTimeout == 10*time.Second, 10 * time.Second},
+		{"max connections from env overrides files", config.Server.MaxConns == 300, 300},
+		{"http addr from flag overrides env", config.Server.HTTPAddr == ":6060", ":6060"},
+		{"boolean flag", config.Server.TLS, true},
+		{"pointer from toml", config.Server.OptionalPort != nil && *config.Server.OptionalPort == 8443, 8443},
+	} {
+		if !check.ok {
+			t.Errorf("%v: expected %v", check.name, check.expected)
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		var config appConfig
+		err := Loader{LookupEnv: env(nil), Args: []string{"--name", "foo", "--server.max_connections=1"}}.Load(&config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.Log.Level != zapcore.InfoLevel || config.Log.Encoding != "json" || config.Server.ReadTimeout != 5*time.Second ||
+			len(config.Log.OutputPaths) != 1 {
+			t.Errorf("defaults were not applied: %+v", config)
+		}
+	})
+
+	t.Run("mixed args", func(t *testing.T) {
+		var config appConfig
+		err := Loader{LookupEnv: env(nil), Args: []string{
+			"serve",
+			"--server.tls", "input.txt",
+			"-v",
+			"--name", "foo",
+			"--output", "out.txt",
+			"--server.max_connections", "5",
+			"--", "--name=bar",
+		}}.Load(&config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.Name != "foo" || !config.Server.TLS || config.Server.MaxConns != 5 {
+			t.Errorf("config flags were not applied: %+v", config)
+		}
+	})
+
+	t.Run("explicit boolean flag value", func(t *testing.T) {
+		var config appConfig
+		err := Loader{LookupEnv: env(nil), Args: []string{"--name=foo", "--server.tls=false", "--server.max_connections=1"}}.Load(&config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.Server.TLS {
+			t.Errorf("TLS should be disabled: %+v", config)
+		}
+	})
+
+	t.Run("flag without a value", func(t *testing.T) {
+		var config appConfig
+		err := Loader{LookupEnv: env(nil), Args: []string{"--server.max_connections=1", "--name"}}.Load(&config)
+		if err == nil || !strings.Contains(err.Error(), "flag needs a value: --name") {
+			t.Errorf("loading should fail: %v", err)
+		}
+	})
+}
+
+func TestLoader_Load_Errors(t *testing.T) {
+	file := writeFile(t, "app.yaml", `
+name: foo
+log:
+  level: verbose
+  encoding: xml
+server:
+  max_connections: 0
+  unknown: true
+`)
+	var config appConfig
+	err := Loader{
+		Files:     []string{file},
+		EnvPrefix: "APP",
+		LookupEnv: env(map[string]string{"APP_SERVER_READ_TIMEOUT": "soon"}),
+		Args:      []string{"--server.bogus=1"},
+	}.Load(&config)
+	if err == nil {
+		t.Fatal("loading should fail")
+	}
+	t.Log(err)
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("errors should be reported as FieldErrors: %v", err)
+	}
+	for _, expected := range []string{
+		"config field log.level (file " + file + ")",
+		"config field server.unknown (file " + file + "): unknown config field",
+		"config field server.read_timeout (env APP_SERVER_READ_TIMEOUT): invalid duration",
+		"config field server.bogus (flag --server.bogus): unknown config field",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("error should contain %q", expected)
+		}
+	}
+
+	t.Run("validation errors report the value source", func(t *testing.T) {
+		file := writeFile(t, "app.yaml", `
+log:
+  encoding: xml
+server:
+  max_connections: 0
+`)
+		var config appConfig
+		err := Loader{Files: []string{file}, LookupEnv: env(nil)}.Load(&config)
+		t.Log(err)
+		for _, expected := range []string{
+			"config field name (not set): value is required",
+			"config field log.encoding (file " + file + "): must be one of [json console]",
+			"config field server.max_connections (file " + file + "): must be >= 1",
+		} {
+			if err == nil || !strings.Contains(err.Error(), expected) {
+				t.Errorf("error should contain %q", expected)
+			}
+		}
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		if err := (Loader{}).Load(config); err == nil {
+			t.Error("config must be a pointer")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if err := (Loader{Files: []string{"missing.yaml"}}).Load(&config); err == nil {
+			t.Error("loading a missing file should fail")
+		}
+	})
+
+	t.Run("unsupported file format", func(t *testing.T) {
+		file := writeFile(t, "app.json", `{}`)
+		if err := (Loader{Files: []string{file}}).Load(&config); err == nil {
+			t.Error("loading a json file should fail")
+		}
+	})
+}
+
+func TestSection(t *testing.T) {
+	file := writeFile(t, "app.yaml", `
+log:
+  level: warn
+server:
+  max_connections: 10
+`)
+	var log logConfig
+	var server serverConfig
+	app := fx.New(
+		fx.NopLogger,
+		fx.Supply(Loader{
+			Files:     []string{file},
+			EnvPrefix: "APP",
+			LookupEnv: env(map[string]string{"APP_LOG_ENCODING": "console"}),
+			Args:      []string{"--server.tls", "--server.max_connections", "20", "--log.output_paths", "stderr"},
+		}),
+		Section[logConfig]("log"),
+		Section[serverConfig]("server"),
+		fx.Populate(&log, &server),
+	)
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = app.Stop(context.Background())
+	}()
+	if log.Level != zapcore.WarnLevel || log.Encoding != "console" || log.OutputPaths[0] != "stderr" {
+		t.Errorf("log config section does not match: %+v", log)
+	}
+	if server.MaxConns != 20 || !server.TLS {
+		t.Errorf("server config section does not match: %+v", server)
+	}
+
+	t.Run("invalid section", func(t *testing.T) {
+		app := fx.New(
+			fx.NopLogger,
+			fx.Supply(Loader{Args: []string{"--server.max_connections=0"}, LookupEnv: env(nil)}),
+			Section[serverConfig]("server"),
+			fx.Populate(&server),
+		)
+		if err := app.Err(); err == nil || !strings.Contains(err.Error(), "server.max_connections") {
+			t.Errorf("app should fail to initialize: %v", err)
+		}
+	})
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Name":        "name",
+		"OutputPaths": "output_paths",
+		"HTTPAddr":    "http_addr",
+		"ID":          "id",
+		"AppID":       "app_id",
+		"Field1":      "field1",
+	} {
+		if actual := snakeCase(name); actual != expected {
+			t.Errorf("%v: expected %v, but was %v", name, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isNested reports whether the type is a nested config struct, as opposed to a value that is decoded as a whole
+func isNested(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// setValue decodes the value into the field.
+//
+// The value is either a string, which is parsed, or a value decoded from a config file, e.g., an int64 or a []any.
+func setValue(field reflect.Value, value any) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return setValue(field.Elem(), value)
+	}
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text(value)))
+	}
+
+	switch field.Kind() {
+	case reflect.Slice:
+		return setSlice(field, value)
+	case reflect.Map:
+		return setMap(field, value)
+	}
+
+	s := text(value)
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("invalid bool: %q", s)
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == durationType {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return fmt.Errorf("invalid duration: %q", s)
+			}
+			field.SetInt(int64(d))
+			return nil
+		}
+		i, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid int: %q", s)
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid uint: %q", s)
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid float: %q", s)
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported config field type: %v", field.Type())
+	}
+	return nil
+}
+
+// setSlice decodes lists from config files, or comma separated strings from environment variables and flags
+func setSlice(field reflect.Value, value any) error {
+	var elems []any
+	switch v := value.(type) {
+	case []any:
+		elems = v
+	case string:
+		if v != "" {
+			for _, elem := range strings.Split(v, ",") {
+				elems = append(elems, strings.TrimSpace(elem))
+			}
+		}
+	default:
+		return fmt.Errorf("expected a list, but was: %T", value)
+	}
+	slice := reflect.MakeSlice(field.Type(), len(elems), len(elems))
+	for i, elem := range elems {
+		if err := setValue(slice.Index(i), elem); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	field.Set(slice)
+	return nil
+}
+
+// setMap decodes tables from config files, or comma separated key=value pairs from environment variables and flags
+func setMap(field reflect.Value, value any) error {
+	if field.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("unsupported config field type: %v", field.Type())
+	}
+	entries := make(map[string]any)
+	switch v := value.(type) {
+	case map[string]any:
+		entries = v
+	case string:
+		if v != "" {
+			for _, entry := range strings.Split(v, ",") {
+				key, val, ok := strings.Cut(entry, "=")
+				if !ok {
+					return fmt.Errorf("expected key=value, but was: %q", entry)
+				}
+				entries[strings.TrimSpace(key)] = strings.TrimSpace(val)
+			}
+		}
+	default:
+		return fmt.Errorf("expected a table, but was: %T", value)
+	}
+	m := reflect.MakeMapWithSize(field.Type(), len(entries))
+	for key, val := range entries {
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if err := setValue(elem, val); err != nil {
+			return fmt.Errorf("[%v]: %w", key, err)
+		}
+		m.SetMapIndex(reflect.ValueOf(key).Convert(field.Type().Key()), elem)
+	}
+	field.Set(m)
+	return nil
+}
+
+// text converts a value decoded from a config file to text
+func text(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
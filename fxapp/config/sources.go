@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SourceKind identifies the kind of config source
+type SourceKind int
+
+const (
+	Default SourceKind = iota + 1
+	File
+	Env
+	Flag
+)
+
+var sourceKindNames = map[SourceKind]string{
+	Default: "default",
+	File:    "file",
+	Env:     "env",
+	Flag:    "flag",
+}
+
+func (k SourceKind) String() string {
+	if name, ok := sourceKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("SourceKind(%d)", int(k))
+}
+
+// Source identifies where a config value came from, e.g., the file path or the environment variable name
+type Source struct {
+	Kind SourceKind
+	Name string
+}
+
+func (s Source) String() string {
+	switch {
+	case s.Kind == 0:
+		return "not set"
+	case s.Name == "":
+		return s.Kind.String()
+	default:
+		return s.Kind.String() + " " + s.Name
+	}
+}
+
+// readFile decodes the YAML or TOML file into a tree of tables
+func readFile(file string) (map[string]any, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	tree := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(file)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("unsupported config file format: %v", file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config file: %v: %w", file, err)
+	}
+	return tree, nil
+}
+
+// lookupTree returns the subtree at the dotted path
+func lookupTree(tree map[string]any, path string) (map[string]any, bool) {
+	if path == "" {
+		return tree, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		subtree, ok := tree[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		tree = subtree
+	}
+	return tree, true
+}
+
+// parseFlags parses the command line flags for the config fields into a map keyed by field path. fields maps each
+// field path to whether the field is a bool.
+//
+// Supported forms are: --path=value, --path value, and --path for boolean true. A single leading dash is also accepted.
+// Like the flag package, boolean fields only take a value via --path=value, i.e., the next arg is never consumed as
+// their value. Any other arg is left alone: positional args are skipped, as are flags for other fields, along with
+// the next arg if it is not a flag, because it may be the flag's value. Parsing stops at the "--" terminator.
+func parseFlags(args []string, fields map[string]bool) (map[string]string, error) {
+	flags := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if !isFlag(arg) {
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		isBool, known := fields[name]
+		switch {
+		case !known:
+			if !hasValue && i+1 < len(args) && !isFlag(args[i+1]) && args[i+1] != "--" {
+				i++
+			}
+			flags[name] = value
+		case hasValue:
+			flags[name] = value
+		case isBool:
+			flags[name] = "true"
+		case i+1 < len(args):
+			flags[name] = args[i+1]
+			i++
+		default:
+			return nil, fmt.Errorf("flag needs a value: %v", arg)
+		}
+	}
+	return flags, nil
+}
+
+func isFlag(arg string) bool {
+	return strings.HasPrefix(arg, "-") && arg != "-"
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldError reports an invalid config field value
+type FieldError struct {
+	Path   string // dotted field path, e.g., log.level
+	Source Source // where the value came from
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("config field %v (%v): %v", e.Path, e.Source, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Validate validates the config struct that v points to via `validate` struct tags.
+//
+// The tag is a comma separated list of rules:
+//   - required - the value must not be the zero value
+//   - min=n, max=n - numbers must be within the range; for strings, slices and maps the length must be within the range.
+//     For time.Duration fields, the limits are durations, e.g., min=1s
+//   - oneof=a b c - the value must be one of the space separated values
+//
+// Nested structs are validated recursively. Errors are returned as FieldErrors joined together.
+func Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a struct: %T", v)
+	}
+	return validate(rv, "", nil)
+}
+
+func validate(v reflect.Value, path string, sources map[string]Source) error {
+	var errs []error
+	walk(v, path, func(field reflect.Value, sf reflect.StructField, path string) {
+		tag := sf.Tag.Get("validate")
+		if tag == "" {
+			return
+		}
+		for _, rule := range strings.Split(tag, ",") {
+			if err := validateRule(field, strings.TrimSpace(rule)); err != nil {
+				errs = append(errs, &FieldError{Path: path, Source: sources[path], Err: err})
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+func validateRule(field reflect.Value, rule string) error {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if field.IsZero() {
+			return errors.New("value is required")
+		}
+	case "min", "max":
+		limit, err := parseLimit(field, arg)
+		if err != nil {
+			return fmt.Errorf("invalid validation rule: %q", rule)
+		}
+		value, ok := measure(field)
+		if !ok {
+			return fmt.Errorf("validation rule is not supported for type %v: %q", field.Type(), rule)
+		}
+		if name == "min" && value < limit {
+			return fmt.Errorf("must be >= %v: %v", arg, field.Interface())
+		}
+		if name == "max" && value > limit {
+			return fmt.Errorf("must be <= %v: %v", arg, field.Interface())
+		}
+	case "oneof":
+		value := fmt.Sprint(field.Interface())
+		if !slices.Contains(strings.Fields(arg), value) {
+			return fmt.Errorf("must be one of [%v]: %q", arg, value)
+		}
+	default:
+		return fmt.Errorf("unknown validation rule: %q", rule)
+	}
+	return nil
+}
+
+// parseLimit parses the min / max limit, which is a duration for time.Duration fields, e.g., min=1s
+func parseLimit(field reflect.Value, limit string) (float64, error) {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(limit)
+		return float64(d), err
+	}
+	return strconv.ParseFloat(limit, 64)
+}
+
+// measure returns the number's value, or the length of strings, slices and maps
+func measure(field reflect.Value) (float64, bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(field.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(field.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), true
+	case reflect.String, reflect.Slice, reflect.Map:
+		return float64(field.Len()), true
+	default:
+		return 0, false
+	}
+}
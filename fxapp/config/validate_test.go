@@ -0,0 +1,63 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	type nested struct {
+		Port uint16 `validate:"min=1024"`
+	}
+	type config struct {
+		Name     string        `validate:"required,max=5"`
+		Ratio    float64       `validate:"min=0,max=1"`
+		Tags     []string      `validate:"min=1"`
+		Mode     string        `validate:"oneof=a b"`
+		Interval time.Duration `validate:"min=1s,max=1m"`
+		Nested   nested
+	}
+	valid := config{Name: "foo", Ratio: 0.5, Tags: []string{"a"}, Mode: "b", Interval: time.Second, Nested: nested{Port: 8080}}
+	if err := Validate(valid); err != nil {
+		t.Errorf("config should be valid: %v", err)
+	}
+	if err := Validate(&valid); err != nil {
+		t.Errorf("config pointer should be valid: %v", err)
+	}
+
+	for name, invalid := range map[string]func(c *config){
+		"required":     func(c *config) { c.Name = "" },
+		"max length":   func(c *config) { c.Name = "foobar" },
+		"min":          func(c *config) { c.Ratio = -1 },
+		"max":          func(c *config) { c.Ratio = 2 },
+		"min length":   func(c *config) { c.Tags = nil },
+		"oneof":        func(c *config) { c.Mode = "c" },
+		"min duration": func(c *config) { c.Interval = time.Millisecond },
+		"max duration": func(c *config) { c.Interval = time.Hour },
+		"nested":       func(c *config) { c.Nested.Port = 80 },
+	} {
+		c := valid
+		invalid(&c)
+		err := Validate(c)
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			t.Errorf("%v: config should be invalid: %v", name, err)
+			continue
+		}
+		t.Logf("%v: %v", name, err)
+	}
+
+	t.Run("invalid rules", func(t *testing.T) {
+		if err := Validate(struct {
+			Name string `validate:"bogus"`
+		}{}); err == nil {
+			t.Error("unknown rule should fail")
+		}
+		if err := Validate(struct {
+			Enabled bool `validate:"min=1"`
+		}{}); err == nil {
+			t.Error("min rule is not supported for bool")
+		}
+	})
+}
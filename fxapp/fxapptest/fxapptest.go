@@ -0,0 +1,20 @@
+// Package fxapptest provides test helpers for fxapp apps
+package fxapptest
+
+import (
+	"github.com/oysterpack/oysterpack-smart-go/fxapp"
+	"go.uber.org/fx"
+	"testing"
+)
+
+// Validate checks that the options, combined with the fxapp options, form a complete app graph, i.e., no dependencies
+// are missing and there are no dependency cycles. Constructors and invoked functions are not run, and the app is not
+// started.
+//
+// The test fails if the graph is not complete.
+func Validate(t testing.TB, options ...fx.Option) {
+	t.Helper()
+	if err := fx.ValidateApp(fxapp.Options(options...)); err != nil {
+		t.Fatalf("app graph is not complete:\n%v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package fxapptest
+
+import (
+	"fmt"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+)
+
+// recorder records test failures without failing the test
+type recorder struct {
+	testing.TB
+	failure string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failure = fmt.Sprintf(format, args...)
+}
+
+func TestValidate(t *testing.T) {
+	constructed := false
+	Validate(t,
+		fx.Provide(func() *zap.Logger {
+			constructed = true
+			return zap.NewNop()
+		}),
+		fx.Invoke(func(*zap.Logger) {}),
+	)
+	if constructed {
+		t.Error("constructors should not be run")
+	}
+
+	t.Run("missing dependency", func(t *testing.T) {
+		r := &recorder{TB: t}
+		Validate(r, fx.Invoke(func(*zap.Logger) {}))
+		t.Log(r.failure)
+		if !strings.Contains(r.failure, "missing type: *zap.Logger") {
+			t.Errorf("missing dependency should be reported: %v", r.failure)
+		}
+	})
+}
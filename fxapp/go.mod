@@ -3,15 +3,21 @@ module github.com/oysterpack/oysterpack-smart-go/fxapp
 go 1.21.4
 
 require (
-	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/objx v0.5.0 // indirect
+	github.com/BurntSushi/toml v1.3.2
+	github.com/benbjohnson/clock v1.3.5
+	github.com/oklog/ulid/v2 v2.1.0
+	github.com/oysterpack/oysterpack-smart-go/core v0.0.0-unpublished
+	github.com/robfig/cron/v3 v3.0.1
+	go.uber.org/fx v1.23.0
+	go.uber.org/zap v1.26.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
 	github.com/stretchr/testify v1.8.4 // indirect
-	go.uber.org/atomic v1.7.0 // indirect
-	go.uber.org/dig v1.17.0 // indirect
-	go.uber.org/fx v1.20.1 // indirect
+	go.uber.org/dig v1.18.0 // indirect
 	go.uber.org/multierr v1.10.0 // indirect
-	go.uber.org/zap v1.26.0 // indirect
-	golang.org/x/sys v0.0.0-20220412211240-33da011f77ad // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/sys v0.11.0 // indirect
 )
+
+replace github.com/oysterpack/oysterpack-smart-go/core v0.0.0-unpublished => ../core
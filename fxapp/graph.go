@@ -0,0 +1,96 @@
+package fxapp
+
+import (
+	"bufio"
+	"encoding/json"
+	"go.uber.org/fx"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GraphHandlerName is the name of the http.Handler that reports the app dependency graph.
+//
+// The graph is reported in DOT format, unless JSON is requested via the query param: ?format=json
+const GraphHandlerName = "app.graph"
+
+// Graph is the app dependency graph
+type Graph struct {
+	Constructors []GraphConstructor `json:"constructors"`
+	// Groups maps value groups to the values that are provided to the group
+	Groups map[string][]string `json:"groups,omitempty"`
+}
+
+// GraphConstructor is a constructor in the app dependency graph.
+//
+// Inputs and outputs are identified by type, including their name or group if specified, e.g., *zap.Logger,
+// http.Handler[name=healthcheck]
+type GraphConstructor struct {
+	Name string `json:"name"`
+	// Package is the package that the constructor is defined in
+	Package string   `json:"package"`
+	Inputs  []string `json:"inputs,omitempty"`
+	Outputs []string `json:"outputs,omitempty"`
+}
+
+var (
+	dotClusterPattern     = regexp.MustCompile(`^subgraph cluster_\d+ \{$`)
+	dotLabelPattern       = regexp.MustCompile(`^label = "(.*)";$`)
+	dotConstructorPattern = regexp.MustCompile(`^constructor_(\d+) \[.*label="(.*?)"`)
+	dotNodePattern        = regexp.MustCompile(`^"(.*?)" \[.*label=<`)
+	dotInputPattern       = regexp.MustCompile(`^constructor_(\d+) -> "(.*?)"`)
+	dotGroupPattern       = regexp.MustCompile(`^"(.*?)" -> "(.*?)"`)
+)
+
+// NewGraph converts the fx.DotGraph into a Graph.
+//
+// fx only exposes the graph in DOT format, which is generated by dig. Thus, the DOT graph is parsed.
+func NewGraph(dot fx.DotGraph) Graph {
+	graph := Graph{Groups: make(map[string][]string)}
+	constructors := make(map[int]int) // DOT constructor ID -> Graph.Constructors index
+	var current *GraphConstructor
+	scanner := bufio.NewScanner(strings.NewReader(string(dot)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case dotClusterPattern.MatchString(line):
+			graph.Constructors = append(graph.Constructors, GraphConstructor{})
+			current = &graph.Constructors[len(graph.Constructors)-1]
+		case line == "}":
+			current = nil
+		case current != nil:
+			if match := dotLabelPattern.FindStringSubmatch(line); match != nil {
+				current.Package = match[1]
+			} else if match := dotConstructorPattern.FindStringSubmatch(line); match != nil {
+				id, _ := strconv.Atoi(match[1])
+				constructors[id] = len(graph.Constructors) - 1
+				current.Name = match[2]
+			} else if match := dotNodePattern.FindStringSubmatch(line); match != nil {
+				current.Outputs = append(current.Outputs, match[1])
+			}
+		default:
+			if match := dotInputPattern.FindStringSubmatch(line); match != nil {
+				id, _ := strconv.Atoi(match[1])
+				if i, ok := constructors[id]; ok {
+					graph.Constructors[i].Inputs = append(graph.Constructors[i].Inputs, match[2])
+				}
+			} else if match := dotGroupPattern.FindStringSubmatch(line); match != nil {
+				graph.Groups[match[1]] = append(graph.Groups[match[1]], match[2])
+			}
+		}
+	}
+	return graph
+}
+
+func newGraphHandler(dot fx.DotGraph) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(NewGraph(dot))
+			return
+		}
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		_, _ = w.Write([]byte(dot))
+	})
+}
@@ -0,0 +1,85 @@
+package fxapp_test
+
+import (
+	"encoding/json"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type greeter struct{}
+
+func newGreeter(*zap.Logger) greeter {
+	return greeter{}
+}
+
+func newGreeting() string {
+	return "ciao"
+}
+
+func findConstructor(graph fxapp.Graph, name string) (fxapp.GraphConstructor, bool) {
+	for _, constructor := range graph.Constructors {
+		if constructor.Name == name {
+			return constructor, true
+		}
+	}
+	return fxapp.GraphConstructor{}, false
+}
+
+func TestNewGraph(t *testing.T) {
+	var dot fx.DotGraph
+	var graphHandler struct {
+		fx.In
+		Handler http.Handler `name:"app.graph"`
+	}
+	app := fxapp.New(
+		fx.Provide(
+			newAppLogger,
+			newGreeter,
+			fx.Annotate(newGreeting, fx.ResultTags(`group:"greetings"`)),
+		),
+		fx.Populate(&dot, &graphHandler),
+	)
+	if err := app.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	graph := fxapp.NewGraph(dot)
+	constructor, ok := findConstructor(graph, "newGreeter")
+	if !ok {
+		t.Fatalf("constructor should be in the graph: %v", graph.Constructors)
+	}
+	if constructor.Package != "github.com/oysterpack/oysterpack-smart-go/fxapp_test" ||
+		!slices.Equal(constructor.Inputs, []string{"*zap.Logger"}) ||
+		!slices.Equal(constructor.Outputs, []string{"fxapp_test.greeter"}) {
+		t.Errorf("constructor does not match: %+v", constructor)
+	}
+	if values := graph.Groups["[type=string group=greetings]"]; len(values) != 1 {
+		t.Errorf("group should be in the graph: %v", graph.Groups)
+	}
+
+	t.Run("DOT", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		graphHandler.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if w.Body.String() != string(dot) || !strings.HasPrefix(w.Body.String(), "digraph {") {
+			t.Errorf("DOT graph does not match: %v", w.Body)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		graphHandler.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?format=json", nil))
+		var reported fxapp.Graph
+		if err := json.Unmarshal(w.Body.Bytes(), &reported); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := findConstructor(reported, "newGreeter"); !ok || len(reported.Constructors) != len(graph.Constructors) {
+			t.Errorf("JSON graph does not match: %v", w.Body)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package fxapp
+
+import (
+	"encoding/json"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/logging"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+)
+
+// InfoHandlerName is the name of the http.Handler that reports the AppInfo as JSON
+const InfoHandlerName = "app.info"
+
+// Identity identifies the application and its release.
+//
+// Applications specify their identity by supplying it, e.g., fx.Supply(fxapp.Identity{...}).
+type Identity struct {
+	// Name is the application name. If not specified, then the executable name is used.
+	Name string `json:"name"`
+	// ID uniquely identifies the application across all releases
+	ID ulid.ULID `json:"id"`
+	// ReleaseID uniquely identifies the application release
+	ReleaseID ulid.ULID `json:"release_id"`
+}
+
+// AppInfo describes the running application instance
+type AppInfo struct {
+	Identity
+	// InstanceID uniquely identifies the application process, i.e., a new instance ID is generated each time the
+	// application is run
+	InstanceID ulid.ULID `json:"instance_id"`
+	Build      BuildInfo `json:"build"`
+}
+
+// MarshalLogObject logs the AppInfo as a zap object
+func (a AppInfo) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", a.Name)
+	enc.AddString("id", a.ID.String())
+	enc.AddString("release_id", a.ReleaseID.String())
+	enc.AddString("instance_id", a.InstanceID.String())
+	if a.Build.Revision != "" {
+		enc.AddString("revision", a.Build.Revision)
+	}
+	return nil
+}
+
+// BuildInfo is the build information embedded in the binary - see debug.ReadBuildInfo
+type BuildInfo struct {
+	// Path is the main package path
+	Path string `json:"path,omitempty"`
+	// Version is the main module version
+	Version   string `json:"version,omitempty"`
+	GoVersion string `json:"go_version,omitempty"`
+	// Revision is the VCS revision that the binary was built from
+	Revision string `json:"revision,omitempty"`
+	// Time is the VCS commit time
+	Time time.Time `json:"time"`
+	// Modified is true if the source tree had local modifications
+	Modified bool `json:"modified,omitempty"`
+}
+
+// ReadBuildInfo returns the build information embedded in the running binary.
+//
+// If the binary was built without module support, then the zero value is returned.
+func ReadBuildInfo() BuildInfo {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return BuildInfo{}
+	}
+	build := BuildInfo{
+		Path:      info.Path,
+		Version:   info.Main.Version,
+		GoVersion: info.GoVersion,
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.Time, _ = time.Parse(time.RFC3339, setting.Value)
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+}
+
+type appInfoParams struct {
+	fx.In
+
+	Identity Identity `optional:"true"`
+}
+
+func newAppInfo(params appInfoParams) AppInfo {
+	identity := params.Identity
+	if identity.Name == "" {
+		identity.Name = filepath.Base(os.Args[0])
+	}
+	return AppInfo{
+		Identity:   identity,
+		InstanceID: ulid.Make(),
+		Build:      ReadBuildInfo(),
+	}
+}
+
+// logField is the "app" logger field
+func logField(info AppInfo) zap.Field {
+	return zap.Object("app", info)
+}
+
+type loggerParams struct {
+	fx.In
+
+	Logger *zap.Logger
+	Info   AppInfo
+	Fields logging.Fields `optional:"true"`
+}
+
+// decorateLogger adds the AppInfo to the logger as the "app" field, unless the logger was built by logging.Module,
+// which already added it
+func decorateLogger(params loggerParams) *zap.Logger {
+	appField := logField(params.Info)
+	for _, field := range params.Fields {
+		if field.Key == appField.Key {
+			return params.Logger
+		}
+	}
+	return params.Logger.With(appField)
+}
+
+func newInfoHandler(info AppInfo) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(info)
+	})
+}
@@ -0,0 +1,88 @@
+package fxapp_test
+
+import (
+	"encoding/json"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAppInfo(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	identity := fxapp.Identity{
+		Name:      "foo",
+		ID:        ulid.MustParse("01HHB6T0SGXCQ4JW6DKDCNYK3R"),
+		ReleaseID: ulid.MustParse("01HHB6T0SGTTKZ8BBMCQ7BMYTG"),
+	}
+	var info fxapp.AppInfo
+	var infoHandler struct {
+		fx.In
+		Handler http.Handler `name:"app.info"`
+	}
+	app := fxapp.New(
+		fx.Supply(zap.New(core), identity),
+		fx.Populate(&info, &infoHandler),
+		fx.Invoke(func() {
+			zap.L().Info("running")
+		}),
+	)
+	startApp(t, app)
+	defer stopApp(t, app)
+
+	if info.Identity != identity {
+		t.Errorf("identity does not match: %v", info.Identity)
+	}
+	if info.InstanceID == (ulid.ULID{}) {
+		t.Error("instance ID should be generated")
+	}
+	if info.Build.GoVersion == "" {
+		t.Error("Go version should be set")
+	}
+
+	t.Run("logger fields", func(t *testing.T) {
+		entries := logs.FilterMessage("running").All()
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 log entry, but was %v", len(entries))
+		}
+		fields := entries[0].ContextMap()["app"].(map[string]any)
+		t.Log(fields)
+		if fields["name"] != "foo" || fields["id"] != identity.ID.String() ||
+			fields["release_id"] != identity.ReleaseID.String() || fields["instance_id"] != info.InstanceID.String() {
+			t.Errorf("app fields do not match: %v", fields)
+		}
+	})
+
+	t.Run("info handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		infoHandler.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		t.Log(w.Body.String())
+		var reported fxapp.AppInfo
+		if err := json.Unmarshal(w.Body.Bytes(), &reported); err != nil {
+			t.Fatal(err)
+		}
+		if reported.Identity != identity || reported.InstanceID != info.InstanceID ||
+			reported.Build.GoVersion != info.Build.GoVersion {
+			t.Errorf("reported info does not match: %v", reported)
+		}
+	})
+}
+
+func TestAppInfo_DefaultIdentity(t *testing.T) {
+	var info fxapp.AppInfo
+	app := fxapp.New(
+		fx.Provide(newAppLogger),
+		fx.Populate(&info),
+	)
+	startApp(t, app)
+	defer stopApp(t, app)
+
+	if info.Name == "" {
+		t.Error("name should default to the executable name")
+	}
+}
@@ -0,0 +1,120 @@
+// Code generated by errgen from errors.yaml. DO NOT EDIT.
+
+package job
+
+import (
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrInvalidJob       = ulid.MustParse("01M54170KTWF28HW4Y89Q3RMBK")
+	ErrDuplicateJobName = ulid.MustParse("01M54170KTWF28HW4Y8ADHSCQC")
+	ErrJobTimeout       = ulid.MustParse("01M54170KTWF28HW4Y8DCFYJVN")
+	ErrJobPanic         = ulid.MustParse("01M54170KTWF28HW4Y8DKPAPN7")
+	ErrJobsNotDrained   = ulid.MustParse("01M542M9D5R5RGPW7393X4Y76W")
+)
+
+var errorDefinitions = []core.ErrorDefinition{
+	{
+		ID:          ErrInvalidJob,
+		Name:        "ErrInvalidJob",
+		Description: "job is missing its name or run func, it does not specify exactly one of a cron expression or an interval, or its cron expression is invalid",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/fxapp/job",
+		Category:    core.InvalidArgument,
+	},
+	{
+		ID:          ErrDuplicateJobName,
+		Name:        "ErrDuplicateJobName",
+		Description: "job name is already registered",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/fxapp/job",
+		Category:    core.AlreadyExists,
+	},
+	{
+		ID:          ErrJobTimeout,
+		Name:        "ErrJobTimeout",
+		Description: "job run did not complete within its timeout",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/fxapp/job",
+		Category:    core.DeadlineExceeded,
+	},
+	{
+		ID:          ErrJobPanic,
+		Name:        "ErrJobPanic",
+		Description: "job run panicked",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/fxapp/job",
+		Category:    core.Internal,
+	},
+	{
+		ID:          ErrJobsNotDrained,
+		Name:        "ErrJobsNotDrained",
+		Description: "running jobs did not return before the stop deadline",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/fxapp/job",
+		Category:    core.DeadlineExceeded,
+	},
+}
+
+func init() {
+	core.RegisterErrors(errorDefinitions...)
+}
+
+func errInvalidJob(job string, cause error) core.Error {
+	return core.Error{
+		ID:       ErrInvalidJob,
+		Name:     "ErrInvalidJob",
+		Err:      fmt.Errorf("invalid job: %v", job),
+		Cause:    cause,
+		Category: core.InvalidArgument,
+	}.With(
+		slog.String("job", job),
+	)
+}
+
+func errDuplicateJobName(job string) core.Error {
+	return core.Error{
+		ID:       ErrDuplicateJobName,
+		Name:     "ErrDuplicateJobName",
+		Err:      fmt.Errorf("job name is already registered: %v", job),
+		Category: core.AlreadyExists,
+	}.With(
+		slog.String("job", job),
+	)
+}
+
+func errJobTimeout(job string, timeout time.Duration, cause error) core.Error {
+	return core.Error{
+		ID:       ErrJobTimeout,
+		Name:     "ErrJobTimeout",
+		Err:      fmt.Errorf("job timed out after %v: %v", timeout, job),
+		Cause:    cause,
+		Category: core.DeadlineExceeded,
+	}.With(
+		slog.String("job", job),
+		slog.Duration("timeout", timeout),
+	)
+}
+
+func errJobPanic(job string, recovered any) core.Error {
+	return core.Error{
+		ID:       ErrJobPanic,
+		Name:     "ErrJobPanic",
+		Err:      fmt.Errorf("job panicked: %v: %v", job, recovered),
+		Category: core.Internal,
+	}.With(
+		slog.String("job", job),
+	)
+}
+
+func errJobsNotDrained(jobs string, cause error) core.Error {
+	return core.Error{
+		ID:       ErrJobsNotDrained,
+		Name:     "ErrJobsNotDrained",
+		Err:      fmt.Errorf("jobs did not stop: %v", jobs),
+		Cause:    cause,
+		Category: core.DeadlineExceeded,
+	}.With(
+		slog.String("jobs", jobs),
+	)
+}
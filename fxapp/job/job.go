@@ -0,0 +1,133 @@
+// Package job runs recurring jobs on cron or fixed interval schedules, e.g., rekey audits and balance snapshots.
+//
+// Jobs are run by a Scheduler. Module ties the Scheduler to the [Fx] app lifecycle.
+//
+// [Fx] = https://uber-go.github.io/fx/
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/robfig/cron/v3"
+	"time"
+)
+
+// DefaultTimeout is the run timeout used for jobs that do not specify one, unless overridden by
+// SchedulerConfig.DefaultTimeout
+const DefaultTimeout = 10 * time.Minute
+
+// Job is a recurring job.
+//
+// Exactly one of Cron or Interval must be specified.
+type Job struct {
+	// Name uniquely identifies the job
+	Name string
+	// Cron is a standard 5 field cron expression, e.g., "30 2 * * *", or a descriptor, e.g., "@hourly".
+	// The time zone can be specified via a CRON_TZ prefix, e.g., "CRON_TZ=America/New_York 0 6 * * *".
+	// Otherwise, the scheduler clock's time zone is used.
+	Cron string
+	// Interval is the fixed delay between the end of a run and the start of the next run
+	Interval time.Duration
+	// Timeout is the maximum time allowed for each run. The run's context is cancelled when the timeout expires.
+	// If zero, then the scheduler's default timeout is used.
+	Timeout time.Duration
+	// Run runs the job
+	Run func(ctx context.Context) error
+}
+
+func (j Job) schedule() (cron.Schedule, error) {
+	var errs []error
+	if j.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if j.Run == nil {
+		errs = append(errs, errors.New("run func is required"))
+	}
+	if j.Timeout < 0 {
+		errs = append(errs, errors.New("timeout must not be negative"))
+	}
+	var schedule cron.Schedule
+	switch {
+	case j.Cron != "" && j.Interval != 0:
+		errs = append(errs, errors.New("cron and interval are mutually exclusive"))
+	case j.Cron != "":
+		var err error
+		if schedule, err = cron.ParseStandard(j.Cron); err != nil {
+			errs = append(errs, err)
+		}
+	case j.Interval > 0:
+		schedule = intervalSchedule(j.Interval)
+	default:
+		errs = append(errs, errors.New("cron or a positive interval is required"))
+	}
+	if len(errs) > 0 {
+		return nil, errInvalidJob(j.Name, errors.Join(errs...))
+	}
+	return schedule, nil
+}
+
+// run runs the job, converting panics into ErrJobPanic errors
+func (j Job) run(ctx context.Context) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errJobPanic(j.Name, recovered)
+		}
+	}()
+	return j.Run(ctx)
+}
+
+type intervalSchedule time.Duration
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(s))
+}
+
+// Outcome is the outcome of a job run
+type Outcome int
+
+const (
+	Succeeded Outcome = iota + 1
+	Failed
+	TimedOut
+	Cancelled // run was cancelled because the scheduler was stopped
+)
+
+var outcomeNames = map[Outcome]string{
+	Succeeded: "Succeeded",
+	Failed:    "Failed",
+	TimedOut:  "TimedOut",
+	Cancelled: "Cancelled",
+}
+
+func (o Outcome) String() string {
+	if name, ok := outcomeNames[o]; ok {
+		return name
+	}
+	if o == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Outcome(%d)", int(o))
+}
+
+// Status is a snapshot of the job's state
+type Status struct {
+	Name string
+	// Running is true while the job is running
+	Running bool
+	// NextRun is when the job is next scheduled to run. It is zero if the job is not scheduled, e.g., the scheduler
+	// is stopped or the job is running.
+	NextRun time.Time
+	// Runs is the number of completed runs
+	Runs int
+	// Failures is the number of runs that failed or timed out. Runs that were cancelled are not failures.
+	Failures int
+	// LastRun is when the last completed run started
+	LastRun time.Time
+	// LastDuration is how long the last completed run took
+	LastDuration time.Duration
+	// LastOutcome is the outcome of the last completed run. It is zero if the job has never run.
+	LastOutcome Outcome
+	// LastError is the error that the last completed run failed with
+	LastError error
+}
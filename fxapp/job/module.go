@@ -0,0 +1,55 @@
+package job
+
+import (
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// jobsGroup is the value group that jobs are provided to
+const jobsGroup = `group:"jobs"`
+
+// Module provides the *Scheduler, which runs all jobs provided via Provide or Supply. If a SchedulerConfig is
+// provided, then it is used to configure the scheduler.
+//
+// The jobs are scheduled when the app starts. When the app stops, running jobs are cancelled, and app shutdown waits
+// for them to return within the stop timeout.
+var Module = fx.Module("job",
+	fx.Provide(newScheduler),
+	fx.Invoke(func(*Scheduler) {}),
+)
+
+// Provide registers job constructors, i.e., functions that return a Job
+func Provide(constructors ...any) fx.Option {
+	options := make([]fx.Option, len(constructors))
+	for i, constructor := range constructors {
+		options[i] = fx.Provide(fx.Annotate(constructor, fx.ResultTags(jobsGroup)))
+	}
+	return fx.Options(options...)
+}
+
+// Supply registers jobs
+func Supply(jobs ...Job) fx.Option {
+	options := make([]fx.Option, len(jobs))
+	for i, job := range jobs {
+		options[i] = fx.Supply(fx.Annotate(job, fx.ResultTags(jobsGroup)))
+	}
+	return fx.Options(options...)
+}
+
+type schedulerParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Logger    *zap.Logger
+	Config    SchedulerConfig `optional:"true"`
+	Jobs      []Job           `group:"jobs"`
+}
+
+func newScheduler(params schedulerParams) (*Scheduler, error) {
+	scheduler, err := NewScheduler(params.Logger, params.Config, params.Jobs...)
+	if err != nil {
+		return nil, err
+	}
+	params.Lifecycle.Append(fx.StartStopHook(scheduler.Start, scheduler.Stop))
+	return scheduler, nil
+}
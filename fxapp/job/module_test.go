@@ -0,0 +1,77 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"testing"
+	"time"
+)
+
+func TestModule(t *testing.T) {
+	newJob := func(name string) Job {
+		return Job{
+			Name:     name,
+			Interval: time.Minute,
+			Run: func(ctx context.Context) error {
+				return nil
+			},
+		}
+	}
+
+	t.Run("jobs run on the schedule using the provided config", func(t *testing.T) {
+		mock := newMockClock()
+		var scheduler *Scheduler
+		app := fxtest.New(t,
+			fx.Supply(zaptest.NewLogger(t), SchedulerConfig{Clock: mock}),
+			Module,
+			Supply(newJob("snapshot")),
+			Provide(func() Job {
+				return newJob("audit")
+			}),
+			fx.Populate(&scheduler),
+		)
+		app.RequireStart()
+		defer app.RequireStop()
+
+		waitFor(t, scheduler, "snapshot", scheduled)
+		waitFor(t, scheduler, "audit", scheduled)
+		mock.Add(time.Minute)
+		waitFor(t, scheduler, "snapshot", runs(1))
+		waitFor(t, scheduler, "audit", runs(1))
+	})
+
+	t.Run("config is optional", func(t *testing.T) {
+		var scheduler *Scheduler
+		app := fxtest.New(t,
+			fx.Supply(zaptest.NewLogger(t)),
+			Module,
+			Supply(newJob("snapshot")),
+			fx.Populate(&scheduler),
+		)
+		start := time.Now()
+		app.RequireStart()
+		defer app.RequireStop()
+
+		status := waitFor(t, scheduler, "snapshot", scheduled)
+		if status.NextRun.Before(start.Add(time.Minute)) {
+			t.Errorf("job should be scheduled using the system clock: %v", status.NextRun)
+		}
+	})
+
+	t.Run("invalid jobs fail app construction", func(t *testing.T) {
+		app := fx.New(
+			fx.NopLogger,
+			fx.Supply(zap.NewNop()),
+			Module,
+			Supply(Job{Name: "snapshot"}),
+		)
+		if err := app.Err(); !errors.Is(err, core.Error{ID: ErrInvalidJob}) {
+			t.Errorf("app construction should fail: %v", err)
+		}
+	})
+}
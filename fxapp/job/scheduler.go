@@ -0,0 +1,248 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"github.com/benbjohnson/clock"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SchedulerConfig configures a Scheduler
+type SchedulerConfig struct {
+	// Clock is used to schedule and time the job runs. If nil, then the system clock is used.
+	// Tests can use a mock clock to make scheduling deterministic - see clock.NewMock.
+	Clock clock.Clock
+	// DefaultTimeout is used for jobs that do not specify a timeout. If zero, then DefaultTimeout is used.
+	DefaultTimeout time.Duration
+}
+
+type scheduledJob struct {
+	Job
+	schedule cron.Schedule
+}
+
+// Scheduler runs jobs on their schedules.
+//
+// A job never overlaps with itself: the next run is scheduled after the current run completes, i.e., scheduled times
+// that pass while the job is running are skipped.
+//
+// It is safe for concurrent use.
+type Scheduler struct {
+	logger *zap.Logger
+	clock  clock.Clock
+	config SchedulerConfig
+	jobs   []scheduledJob
+
+	lock     sync.RWMutex
+	statuses map[string]*Status
+	cancel   context.CancelFunc
+	done     sync.WaitGroup
+}
+
+// NewScheduler constructs a new Scheduler for the jobs.
+//
+// Errors:
+//   - ErrInvalidJob
+//   - ErrDuplicateJobName
+func NewScheduler(logger *zap.Logger, config SchedulerConfig, jobs ...Job) (*Scheduler, error) {
+	var errs []error
+	scheduled := make([]scheduledJob, 0, len(jobs))
+	statuses := make(map[string]*Status, len(jobs))
+	for _, job := range jobs {
+		schedule, err := job.schedule()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, exists := statuses[job.Name]; exists {
+			errs = append(errs, errDuplicateJobName(job.Name))
+			continue
+		}
+		scheduled = append(scheduled, scheduledJob{Job: job, schedule: schedule})
+		statuses[job.Name] = &Status{Name: job.Name}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	jobClock := config.Clock
+	if jobClock == nil {
+		jobClock = clock.New()
+	}
+	return &Scheduler{
+		logger:   logger,
+		clock:    jobClock,
+		config:   config,
+		jobs:     scheduled,
+		statuses: statuses,
+	}, nil
+}
+
+func (s *Scheduler) timeout(job Job) time.Duration {
+	switch {
+	case job.Timeout > 0:
+		return job.Timeout
+	case s.config.DefaultTimeout > 0:
+		return s.config.DefaultTimeout
+	default:
+		return DefaultTimeout
+	}
+}
+
+// Start schedules the jobs to run in the background until the Scheduler is stopped.
+//
+// Starting a Scheduler that is already started is a no-op.
+func (s *Scheduler) Start() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, job := range s.jobs {
+		s.done.Add(1)
+		go s.schedule(ctx, job)
+	}
+}
+
+// Stop stops scheduling the jobs. The context of running jobs is cancelled, and Stop waits for them to return.
+//
+// If the context is done before the running jobs have returned, then ErrJobsNotDrained is returned, with the context
+// error as its cause.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.lock.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.lock.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	drained := make(chan struct{})
+	go func() {
+		s.done.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		var running []string
+		for _, status := range s.Status() {
+			if status.Running {
+				running = append(running, status.Name)
+			}
+		}
+		return errJobsNotDrained(strings.Join(running, ","), ctx.Err())
+	}
+}
+
+// Status returns the status of each job, in the order the jobs were specified
+func (s *Scheduler) Status() []Status {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	statuses := make([]Status, len(s.jobs))
+	for i, job := range s.jobs {
+		statuses[i] = *s.statuses[job.Name]
+	}
+	return statuses
+}
+
+// Lookup returns the status of the named job
+func (s *Scheduler) Lookup(name string) (Status, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if status, ok := s.statuses[name]; ok {
+		return *status, true
+	}
+	return Status{}, false
+}
+
+func (s *Scheduler) update(name string, fn func(status *Status)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fn(s.statuses[name])
+}
+
+func (s *Scheduler) schedule(ctx context.Context, job scheduledJob) {
+	defer s.done.Done()
+	defer s.update(job.Name, func(status *Status) {
+		status.NextRun = time.Time{}
+	})
+	for {
+		now := s.clock.Now()
+		next := job.schedule.Next(now)
+		if next.IsZero() {
+			s.logger.Warn("job has no next run time", zap.String("job", job.Name), zap.String("cron", job.Cron))
+			return
+		}
+		timer := s.clock.Timer(next.Sub(now))
+		s.update(job.Name, func(status *Status) {
+			status.NextRun = next
+		})
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		s.run(ctx, job.Job)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	timeout := s.timeout(job)
+	start := s.clock.Now()
+	s.update(job.Name, func(status *Status) {
+		status.Running = true
+		status.NextRun = time.Time{}
+	})
+
+	runCtx, cancel := s.clock.WithTimeout(ctx, timeout)
+	err := job.run(runCtx)
+	cancel() // the context error is settled once cancelled, i.e., it is DeadlineExceeded only if the deadline fired
+	duration := s.clock.Since(start)
+
+	outcome := Succeeded
+	switch {
+	case err == nil:
+	case ctx.Err() != nil:
+		// the scheduler is being stopped
+		outcome = Cancelled
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		outcome = TimedOut
+		err = errJobTimeout(job.Name, timeout, err)
+	default:
+		outcome = Failed
+	}
+	s.update(job.Name, func(status *Status) {
+		status.Running = false
+		status.Runs++
+		if outcome == Failed || outcome == TimedOut {
+			status.Failures++
+		}
+		status.LastRun = start
+		status.LastDuration = duration
+		status.LastOutcome = outcome
+		status.LastError = err
+	})
+
+	logger := s.logger.With(
+		zap.String("job", job.Name),
+		zap.Stringer("outcome", outcome),
+		zap.Duration("duration", duration),
+	)
+	switch outcome {
+	case Succeeded:
+		logger.Info("job run succeeded")
+	case Cancelled:
+		logger.Info("job run cancelled", zap.Error(err))
+	default:
+		logger.Error("job run failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,337 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"github.com/benbjohnson/clock"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var startTime = time.Date(2026, 1, 1, 0, 0, 30, 0, time.UTC)
+
+func newMockClock() *clock.Mock {
+	mock := clock.NewMock()
+	mock.Set(startTime)
+	return mock
+}
+
+// waitFor waits for the job status to satisfy the condition
+func waitFor(t *testing.T, scheduler *Scheduler, name string, condition func(status Status) bool) Status {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		status, ok := scheduler.Lookup(name)
+		if !ok {
+			t.Fatalf("job is not registered: %v", name)
+		}
+		if condition(status) {
+			return status
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job status condition was not met: %+v", status)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func scheduled(status Status) bool {
+	return !status.NextRun.IsZero()
+}
+
+func running(status Status) bool {
+	return status.Running
+}
+
+func runs(count int) func(status Status) bool {
+	return func(status Status) bool {
+		return status.Runs == count && !status.Running
+	}
+}
+
+func newSchedulerForTest(t *testing.T, mock *clock.Mock, jobs ...Job) *Scheduler {
+	scheduler, err := NewScheduler(zaptest.NewLogger(t), SchedulerConfig{Clock: mock}, jobs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduler.Start()
+	t.Cleanup(func() {
+		if err := scheduler.Stop(context.Background()); err != nil {
+			t.Error(err)
+		}
+	})
+	return scheduler
+}
+
+func TestScheduler(t *testing.T) {
+	t.Run("interval", func(t *testing.T) {
+		mock := newMockClock()
+		release := make(chan struct{})
+		scheduler := newSchedulerForTest(t, mock, Job{
+			Name:     "snapshot",
+			Interval: time.Minute,
+			Run: func(ctx context.Context) error {
+				<-release
+				return nil
+			},
+		})
+		status := waitFor(t, scheduler, "snapshot", scheduled)
+		if !status.NextRun.Equal(startTime.Add(time.Minute)) {
+			t.Errorf("next run does not match: %v", status.NextRun)
+		}
+
+		mock.Add(time.Minute)
+		waitFor(t, scheduler, "snapshot", running)
+		mock.Add(3 * time.Second)
+		close(release)
+		status = waitFor(t, scheduler, "snapshot", runs(1))
+		if status.LastOutcome != Succeeded || status.LastError != nil || status.Failures != 0 {
+			t.Errorf("job run should have succeeded: %+v", status)
+		}
+		if !status.LastRun.Equal(startTime.Add(time.Minute)) || status.LastDuration != 3*time.Second {
+			t.Errorf("last run does not match: %+v", status)
+		}
+		// the interval is the delay between runs
+		status = waitFor(t, scheduler, "snapshot", scheduled)
+		if !status.NextRun.Equal(startTime.Add(2*time.Minute + 3*time.Second)) {
+			t.Errorf("next run does not match: %v", status.NextRun)
+		}
+	})
+
+	t.Run("cron", func(t *testing.T) {
+		mock := newMockClock()
+		var count atomic.Int32
+		scheduler := newSchedulerForTest(t, mock, Job{
+			Name: "audit",
+			Cron: "*/5 * * * *",
+			Run: func(ctx context.Context) error {
+				count.Add(1)
+				return nil
+			},
+		})
+		status := waitFor(t, scheduler, "audit", scheduled)
+		if !status.NextRun.Equal(time.Date(2026, 1, 1, 0, 5, 0, 0, time.UTC)) {
+			t.Errorf("next run does not match: %v", status.NextRun)
+		}
+		mock.Add(4 * time.Minute)
+		if count.Load() != 0 {
+			t.Error("job should not have run yet")
+		}
+		mock.Add(30 * time.Second)
+		waitFor(t, scheduler, "audit", runs(1))
+		status = waitFor(t, scheduler, "audit", scheduled)
+		if !status.NextRun.Equal(time.Date(2026, 1, 1, 0, 10, 0, 0, time.UTC)) {
+			t.Errorf("next run does not match: %v", status.NextRun)
+		}
+	})
+
+	t.Run("runs do not overlap", func(t *testing.T) {
+		mock := newMockClock()
+		release := make(chan struct{})
+		var active, maxActive atomic.Int32
+		scheduler := newSchedulerForTest(t, mock, Job{
+			Name:     "renewal",
+			Interval: time.Second,
+			Run: func(ctx context.Context) error {
+				if n := active.Add(1); n > maxActive.Load() {
+					maxActive.Store(n)
+				}
+				defer active.Add(-1)
+				<-release
+				return nil
+			},
+		})
+		waitFor(t, scheduler, "renewal", scheduled)
+		mock.Add(time.Second)
+		waitFor(t, scheduler, "renewal", running)
+		for i := 0; i < 5; i++ {
+			mock.Add(time.Second)
+		}
+		close(release)
+		waitFor(t, scheduler, "renewal", func(status Status) bool {
+			return status.Runs >= 1
+		})
+		if maxActive.Load() != 1 {
+			t.Errorf("job runs should not overlap: %v", maxActive.Load())
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		mock := newMockClock()
+		scheduler := newSchedulerForTest(t, mock, Job{
+			Name:     "audit",
+			Interval: time.Minute,
+			Timeout:  10 * time.Second,
+			Run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		})
+		waitFor(t, scheduler, "audit", scheduled)
+		mock.Add(time.Minute)
+		waitFor(t, scheduler, "audit", running)
+		mock.Add(10 * time.Second)
+		status := waitFor(t, scheduler, "audit", runs(1))
+		if status.LastOutcome != TimedOut || status.Failures != 1 || status.LastDuration != 10*time.Second {
+			t.Errorf("job run should have timed out: %+v", status)
+		}
+		if !errors.Is(status.LastError, core.Error{ID: ErrJobTimeout}) || !errors.Is(status.LastError, context.DeadlineExceeded) {
+			t.Errorf("unexpected error: %v", status.LastError)
+		}
+	})
+
+	t.Run("job that succeeds as the deadline passes did not time out", func(t *testing.T) {
+		mock := newMockClock()
+		scheduler := newSchedulerForTest(t, mock, Job{
+			Name:     "audit",
+			Interval: time.Minute,
+			Timeout:  10 * time.Second,
+			Run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			},
+		})
+		waitFor(t, scheduler, "audit", scheduled)
+		mock.Add(time.Minute)
+		waitFor(t, scheduler, "audit", running)
+		mock.Add(10 * time.Second)
+		status := waitFor(t, scheduler, "audit", runs(1))
+		if status.LastOutcome != Succeeded || status.Failures != 0 || status.LastError != nil {
+			t.Errorf("job run should have succeeded: %+v", status)
+		}
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		mock := newMockClock()
+		var count atomic.Int32
+		errBoom := errors.New("BOOM!")
+		scheduler := newSchedulerForTest(t, mock, Job{
+			Name:     "snapshot",
+			Interval: time.Minute,
+			Run: func(ctx context.Context) error {
+				if count.Add(1) == 1 {
+					return errBoom
+				}
+				panic("BOOM!")
+			},
+		})
+		waitFor(t, scheduler, "snapshot", scheduled)
+		mock.Add(time.Minute)
+		status := waitFor(t, scheduler, "snapshot", runs(1))
+		if status.LastOutcome != Failed || !errors.Is(status.LastError, errBoom) {
+			t.Errorf("job run should have failed: %+v", status)
+		}
+
+		waitFor(t, scheduler, "snapshot", scheduled)
+		mock.Add(time.Minute)
+		status = waitFor(t, scheduler, "snapshot", runs(2))
+		if status.LastOutcome != Failed || !errors.Is(status.LastError, core.Error{ID: ErrJobPanic}) || status.Failures != 2 {
+			t.Errorf("job run should have failed: %+v", status)
+		}
+	})
+
+	t.Run("stop cancels running jobs", func(t *testing.T) {
+		mock := newMockClock()
+		var cancelled atomic.Bool
+		scheduler, err := NewScheduler(zaptest.NewLogger(t), SchedulerConfig{Clock: mock}, Job{
+			Name:     "audit",
+			Interval: time.Minute,
+			Run: func(ctx context.Context) error {
+				<-ctx.Done()
+				cancelled.Store(true)
+				return ctx.Err()
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		scheduler.Start()
+		waitFor(t, scheduler, "audit", scheduled)
+		mock.Add(time.Minute)
+		waitFor(t, scheduler, "audit", running)
+		if err := scheduler.Stop(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if !cancelled.Load() {
+			t.Error("running job should have been cancelled")
+		}
+		status, _ := scheduler.Lookup("audit")
+		if status.LastOutcome != Cancelled || status.Failures != 0 || !status.NextRun.IsZero() {
+			t.Errorf("cancelled run should not be a failure: %+v", status)
+		}
+	})
+
+	t.Run("stop is bounded by the context", func(t *testing.T) {
+		mock := newMockClock()
+		release := make(chan struct{})
+		defer close(release)
+		// the job logs after the test completes
+		scheduler, err := NewScheduler(zap.NewNop(), SchedulerConfig{Clock: mock}, Job{
+			Name:     "audit",
+			Interval: time.Minute,
+			Run: func(ctx context.Context) error {
+				<-release
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		scheduler.Start()
+		waitFor(t, scheduler, "audit", scheduled)
+		mock.Add(time.Minute)
+		waitFor(t, scheduler, "audit", running)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err = scheduler.Stop(ctx)
+		if !errors.Is(err, core.Error{ID: ErrJobsNotDrained}) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("stop should fail when running jobs do not return in time: %v", err)
+		}
+		if name, _ := core.AttrValue[string](err, "jobs"); name != "audit" {
+			t.Errorf("running jobs should be reported: %v", name)
+		}
+	})
+}
+
+func TestNewScheduler(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+	_, err := NewScheduler(zap.NewNop(), SchedulerConfig{},
+		Job{Name: "a", Interval: time.Minute, Run: noop},
+		Job{Name: "a", Interval: time.Minute, Run: noop},
+		Job{Name: "b", Cron: "every day", Run: noop},
+		Job{Name: "c", Cron: "@daily", Interval: time.Minute, Run: noop},
+		Job{Name: "d", Run: noop},
+		Job{Interval: time.Minute},
+	)
+	t.Log(err)
+	if !errors.Is(err, core.Error{ID: ErrDuplicateJobName}) {
+		t.Errorf("duplicate job name should be reported: %v", err)
+	}
+	var invalid int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		if errors.Is(e, core.Error{ID: ErrInvalidJob}) {
+			invalid++
+		}
+	}
+	if invalid != 4 {
+		t.Errorf("expected 4 invalid jobs, but was %v", invalid)
+	}
+
+	scheduler, err := NewScheduler(zap.NewNop(), SchedulerConfig{DefaultTimeout: time.Minute},
+		Job{Name: "a", Cron: "CRON_TZ=America/New_York 0 6 * * *", Run: noop},
+		Job{Name: "b", Interval: time.Minute, Timeout: time.Second, Run: noop},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeout := scheduler.timeout(scheduler.jobs[0].Job); timeout != time.Minute {
+		t.Errorf("default timeout should be used: %v", timeout)
+	}
+	if timeout := scheduler.timeout(scheduler.jobs[1].Job); timeout != time.Second {
+		t.Errorf("job timeout should be used: %v", timeout)
+	}
+}
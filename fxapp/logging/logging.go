@@ -0,0 +1,145 @@
+// Package logging provides the app's *zap.Logger, built from configuration, as an [Fx] module.
+//
+// [Fx] = https://uber-go.github.io/fx/
+package logging
+
+import (
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"net/http"
+)
+
+// ConfigPath is the config section path for the logging Config
+const ConfigPath = "log"
+
+// LevelHandlerName is the name of the http.Handler that reports and changes the log level at runtime
+const LevelHandlerName = "log.level"
+
+// fieldsGroup is the value group that logger fields are provided to
+const fieldsGroup = `group:"log.fields"`
+
+// Config is the logging config
+type Config struct {
+	// Level is the minimum enabled logging level. It can be changed at runtime - see Module.
+	Level zapcore.Level `default:"info"`
+	// Encoding is either json or console
+	Encoding string `default:"json" validate:"oneof=json console"`
+	// Development puts the logger in development mode, e.g., DPanic level logs panic
+	Development bool
+	// Sampling caps the global CPU and I/O load that logging puts on the process
+	Sampling SamplingConfig
+	// OutputPaths are URLs or file paths to write logging output to, e.g., stdout, stderr, /var/log/app.log
+	OutputPaths []string `default:"stderr" validate:"min=1"`
+	// ErrorOutputPaths are URLs or file paths to write internal logger errors to
+	ErrorOutputPaths []string `default:"stderr" validate:"min=1"`
+	// DisableCaller stops annotating logs with the calling function's file name and line number
+	DisableCaller bool
+	// StacktraceLevel is the level at and above which stack traces are captured
+	StacktraceLevel zapcore.Level `default:"error"`
+	// DisableStacktrace disables stack trace capturing
+	DisableStacktrace bool
+	// InitialFields are added to every log entry, e.g., region, environment
+	InitialFields map[string]string
+}
+
+// SamplingConfig configures log sampling: per second, the first Initial entries with the same level and message are
+// logged, and thereafter every Thereafter-th entry is logged.
+type SamplingConfig struct {
+	// Initial is the number of entries logged per second before sampling kicks in. If zero, then sampling is disabled.
+	Initial    int `default:"100" validate:"min=0"`
+	Thereafter int `default:"100" validate:"min=0"`
+}
+
+// Module provides:
+//   - Config, which is loaded from the config section at [ConfigPath] via the config.Loader that must be provided by the app
+//   - *zap.Logger, with the fields provided via ProvideFields, e.g., fxapp adds the app's identity as the "app" field
+//   - Fields, which are the fields that were added to the logger
+//   - zap.AtomicLevel, which changes the logger's level at runtime
+//   - http.Handler named [LevelHandlerName], which reports the current level via GET and changes it via PUT,
+//     e.g., curl -X PUT -d '{"level":"debug"}'
+var Module = fx.Module("logging",
+	config.Section[Config](ConfigPath),
+	fx.Provide(
+		newLogger,
+		fx.Annotate(
+			newLevelHandler,
+			fx.ResultTags(`name:"`+LevelHandlerName+`"`),
+		),
+	),
+)
+
+// Fields are the fields that are added to the logger that is provided by Module
+type Fields []zap.Field
+
+// ProvideFields registers field constructors, i.e., functions that return a zap.Field, whose fields are added to the
+// logger that is provided by Module
+func ProvideFields(constructors ...any) fx.Option {
+	options := make([]fx.Option, len(constructors))
+	for i, constructor := range constructors {
+		options[i] = fx.Provide(fx.Annotate(constructor, fx.ResultTags(fieldsGroup)))
+	}
+	return fx.Options(options...)
+}
+
+type loggerParams struct {
+	fx.In
+
+	Config Config
+	Fields []zap.Field `group:"log.fields"`
+}
+
+func newLogger(params loggerParams) (*zap.Logger, zap.AtomicLevel, Fields, error) {
+	logger, level, err := New(params.Config)
+	if err != nil {
+		return nil, zap.AtomicLevel{}, nil, err
+	}
+	return logger.With(params.Fields...), level, params.Fields, nil
+}
+
+// New builds the logger from the config.
+//
+// The returned atomic level controls the logger's level, i.e., changing its level changes the logger's level.
+func New(config Config) (*zap.Logger, zap.AtomicLevel, error) {
+	level := zap.NewAtomicLevelAt(config.Level)
+	encoderConfig := zap.NewProductionEncoderConfig()
+	if config.Encoding == "console" {
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
+	}
+	zapConfig := zap.Config{
+		Level:             level,
+		Development:       config.Development,
+		DisableCaller:     config.DisableCaller,
+		DisableStacktrace: config.DisableStacktrace,
+		Encoding:          config.Encoding,
+		EncoderConfig:     encoderConfig,
+		OutputPaths:       config.OutputPaths,
+		ErrorOutputPaths:  config.ErrorOutputPaths,
+	}
+	if config.Sampling.Initial > 0 {
+		zapConfig.Sampling = &zap.SamplingConfig{
+			Initial:    config.Sampling.Initial,
+			Thereafter: config.Sampling.Thereafter,
+		}
+	}
+	if len(config.InitialFields) > 0 {
+		zapConfig.InitialFields = make(map[string]any, len(config.InitialFields))
+		for key, value := range config.InitialFields {
+			zapConfig.InitialFields[key] = value
+		}
+	}
+	var options []zap.Option
+	if !config.DisableStacktrace {
+		options = append(options, zap.AddStacktrace(config.StacktraceLevel))
+	}
+	logger, err := zapConfig.Build(options...)
+	if err != nil {
+		return nil, zap.AtomicLevel{}, err
+	}
+	return logger, level, nil
+}
+
+func newLevelHandler(level zap.AtomicLevel) http.Handler {
+	return level
+}
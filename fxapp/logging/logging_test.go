@@ -0,0 +1,186 @@
+package logging
+
+import (
+	"encoding/json"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/config"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func noEnv(string) (string, bool) {
+	return "", false
+}
+
+func readLogs(t *testing.T, file string) []map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logs []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log entry is not JSON: %v: %v", line, err)
+		}
+		logs = append(logs, entry)
+	}
+	return logs
+}
+
+func TestModule(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "app.log")
+	configFile := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(configFile, []byte(`
+log:
+  level: warn
+  output_paths: [`+logFile+`]
+  initial_fields:
+    region: us-east-1
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logger *zap.Logger
+	var levelHandler http.Handler
+	app := fxtest.New(t,
+		fx.Supply(config.Loader{Files: []string{configFile}, LookupEnv: noEnv}),
+		Module,
+		ProvideFields(func() zap.Field {
+			return zap.String("service", "foo")
+		}),
+		fx.Populate(&logger),
+		fx.Populate(fx.Annotate(&levelHandler, fx.ParamTags(`name:"`+LevelHandlerName+`"`))),
+	)
+	app.RequireStart()
+	defer app.RequireStop()
+
+	logger.Info("dropped")
+	logger.Warn("logged")
+
+	t.Run("change level at runtime", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		levelHandler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"info"}`)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("level change failed: %v: %v", w.Code, w.Body)
+		}
+		logger.Info("logged after level change")
+
+		w = httptest.NewRecorder()
+		levelHandler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if !strings.Contains(w.Body.String(), `"level":"info"`) {
+			t.Errorf("unexpected level: %v", w.Body)
+		}
+	})
+
+	_ = logger.Sync()
+	logs := readLogs(t, logFile)
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 log entries, but was %v: %v", len(logs), logs)
+	}
+	if logs[0]["msg"] != "logged" || logs[1]["msg"] != "logged after level change" {
+		t.Errorf("unexpected log entries: %v", logs)
+	}
+	if logs[0]["region"] != "us-east-1" {
+		t.Errorf("initial fields should be logged: %v", logs[0])
+	}
+	if logs[0]["service"] != "foo" {
+		t.Errorf("provided fields should be logged: %v", logs[0])
+	}
+	if _, ok := logs[0]["caller"]; !ok {
+		t.Errorf("caller should be logged: %v", logs[0])
+	}
+}
+
+func TestNew(t *testing.T) {
+	var defaults Config
+	if err := (config.Loader{LookupEnv: noEnv}).LoadSection(ConfigPath, &defaults); err != nil {
+		t.Fatal(err)
+	}
+	if defaults.Level != zapcore.InfoLevel || defaults.Encoding != "json" || defaults.StacktraceLevel != zapcore.ErrorLevel ||
+		defaults.Sampling.Initial != 100 || defaults.Sampling.Thereafter != 100 {
+		t.Errorf("unexpected defaults: %+v", defaults)
+	}
+
+	t.Run("console encoding without caller and stacktrace", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "app.log")
+		cfg := defaults
+		cfg.Encoding = "console"
+		cfg.OutputPaths = []string{logFile}
+		cfg.DisableCaller = true
+		cfg.DisableStacktrace = true
+		logger, level, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if level.Level() != zapcore.InfoLevel {
+			t.Errorf("unexpected level: %v", level.Level())
+		}
+		logger.Error("failure")
+		_ = logger.Sync()
+		data, err := os.ReadFile(logFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Log(string(data))
+		line := string(data)
+		if strings.HasPrefix(line, "{") || !strings.Contains(line, "failure") || strings.Contains(line, "logging_test.go") {
+			t.Errorf("unexpected log entry: %v", line)
+		}
+	})
+
+	t.Run("stacktrace level", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "app.log")
+		cfg := defaults
+		cfg.OutputPaths = []string{logFile}
+		cfg.StacktraceLevel = zapcore.WarnLevel
+		logger, _, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		logger.Warn("warning")
+		_ = logger.Sync()
+		if logs := readLogs(t, logFile); logs[0]["stacktrace"] == nil {
+			t.Errorf("stacktrace should be logged: %v", logs[0])
+		}
+	})
+
+	t.Run("sampling", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "app.log")
+		cfg := defaults
+		cfg.OutputPaths = []string{logFile}
+		cfg.Sampling = SamplingConfig{Initial: 2, Thereafter: 100}
+		logger, _, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 10; i++ {
+			logger.Info("repeated")
+		}
+		_ = logger.Sync()
+		if logs := readLogs(t, logFile); len(logs) != 2 {
+			t.Errorf("expected sampled logs, but was %v", len(logs))
+		}
+	})
+
+	t.Run("invalid output path", func(t *testing.T) {
+		cfg := defaults
+		cfg.OutputPaths = []string{filepath.Join(t.TempDir(), "missing", "app.log")}
+		if _, _, err := New(cfg); err == nil {
+			t.Error("logger should fail to build")
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package logging
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"log/slog"
+	"runtime"
+)
+
+// NewSlogHandler returns a slog.Handler that writes log records to the zap logger.
+//
+// Levels are mapped to the closest zap level at or below the slog level, e.g., slog.LevelWarn-1 maps to zap.InfoLevel.
+// Attributes are converted to typed zap fields, and groups are logged as nested objects. The caller is taken from
+// the record's PC.
+func NewSlogHandler(logger *zap.Logger) slog.Handler {
+	return &slogHandler{core: logger.Core(), name: logger.Name()}
+}
+
+type slogHandler struct {
+	core zapcore.Core
+	name string
+	// groups that have been opened via WithGroup, but not yet added to the core.
+	// Groups are added lazily because groups with no attributes are omitted.
+	groups []string
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.core.Enabled(zapLevel(level))
+}
+
+func (h *slogHandler) Handle(_ context.Context, record slog.Record) error {
+	entry := zapcore.Entry{
+		Level:      zapLevel(record.Level),
+		Time:       record.Time,
+		LoggerName: h.name,
+		Message:    record.Message,
+	}
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		entry.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+		entry.Caller.Function = frame.Function
+	}
+	checked := h.core.Check(entry, nil)
+	if checked == nil {
+		return nil
+	}
+	fields := make([]zap.Field, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendField(fields, attr)
+		return true
+	})
+	checked.Write(h.withGroups(fields)...)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var fields []zap.Field
+	for _, attr := range attrs {
+		fields = appendField(fields, attr)
+	}
+	if len(fields) == 0 {
+		return h
+	}
+	return &slogHandler{core: h.core.With(h.withGroups(fields)), name: h.name}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := make([]string, len(h.groups), len(h.groups)+1)
+	copy(groups, h.groups)
+	return &slogHandler{core: h.core, name: h.name, groups: append(groups, name)}
+}
+
+// withGroups prepends the pending groups as zap namespaces, unless there are no fields
+func (h *slogHandler) withGroups(fields []zap.Field) []zap.Field {
+	if len(fields) == 0 || len(h.groups) == 0 {
+		return fields
+	}
+	namespaced := make([]zap.Field, 0, len(h.groups)+len(fields))
+	for _, group := range h.groups {
+		namespaced = append(namespaced, zap.Namespace(group))
+	}
+	return append(namespaced, fields...)
+}
+
+func zapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < slog.LevelError:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// appendField converts the attribute to a zap field, following the slog.Handler rules:
+//   - empty attributes are ignored
+//   - groups with no attributes are ignored
+//   - groups with an empty key are inlined
+func appendField(fields []zap.Field, attr slog.Attr) []zap.Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+	value := attr.Value
+	switch value.Kind() {
+	case slog.KindString:
+		return append(fields, zap.String(attr.Key, value.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(attr.Key, value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(attr.Key, value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(attr.Key, value.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(attr.Key, value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(attr.Key, value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(attr.Key, value.Time()))
+	case slog.KindGroup:
+		var group []zap.Field
+		for _, attr := range value.Group() {
+			group = appendField(group, attr)
+		}
+		if len(group) == 0 {
+			return fields
+		}
+		if attr.Key == "" {
+			return append(fields, group...)
+		}
+		return append(fields, zap.Object(attr.Key, groupFields(group)))
+	default:
+		return append(fields, zap.Any(attr.Key, value.Any()))
+	}
+}
+
+type groupFields []zap.Field
+
+func (g groupFields) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, field := range g {
+		field.AddTo(enc)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+)
+
+func TestSlogHandler(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	handler := NewSlogHandler(zap.New(core))
+
+	err := slogtest.TestHandler(handler, func() []map[string]any {
+		var results []map[string]any
+		for _, entry := range logs.TakeAll() {
+			result := entry.ContextMap()
+			if !entry.Time.IsZero() {
+				result[slog.TimeKey] = entry.Time
+			}
+			result[slog.LevelKey] = entry.Level
+			result[slog.MessageKey] = entry.Message
+			results = append(results, result)
+		}
+		return results
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSlogHandler_Levels(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := slog.New(NewSlogHandler(zap.New(core)))
+
+	logger.Debug("dropped")
+	for level, expected := range map[slog.Level]zapcore.Level{
+		slog.LevelInfo:      zapcore.InfoLevel,
+		slog.LevelWarn - 1:  zapcore.InfoLevel,
+		slog.LevelWarn:      zapcore.WarnLevel,
+		slog.LevelError:     zapcore.ErrorLevel,
+		slog.LevelError + 4: zapcore.ErrorLevel,
+	} {
+		logger.Log(context.Background(), level, "message")
+		entries := logs.TakeAll()
+		if len(entries) != 1 || entries[0].Level != expected {
+			t.Errorf("slog level %v should map to zap level %v: %v", level, expected, entries)
+		}
+	}
+	if logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("debug level should not be enabled")
+	}
+}
+
+func TestSlogHandler_Attrs(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := slog.New(NewSlogHandler(zap.New(core).Named("kmd")))
+
+	logger.With("wallet", "foo").WithGroup("handle").Warn("Failed to release KMD handle",
+		"error", errors.New("connection refused"),
+		slog.Uint64("round", 1234),
+	)
+
+	entry := logs.All()[0]
+	if entry.LoggerName != "kmd" {
+		t.Errorf("logger name does not match: %v", entry.LoggerName)
+	}
+	if !strings.HasSuffix(entry.Caller.File, "slog_test.go") {
+		t.Errorf("caller does not match: %v", entry.Caller)
+	}
+	fields := entry.ContextMap()
+	t.Log(fields)
+	if fields["wallet"] != "foo" {
+		t.Errorf("wallet attribute does not match: %v", fields)
+	}
+	handle := fields["handle"].(map[string]any)
+	if handle["error"] != "connection refused" || handle["round"] != uint64(1234) {
+		t.Errorf("grouped attributes do not match: %v", handle)
+	}
+}
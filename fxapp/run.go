@@ -0,0 +1,132 @@
+package fxapp
+
+import (
+	"context"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Process exit codes returned by Run.
+//
+// When the app is shut down via fx.Shutdowner, then the exit code specified via fx.ExitCode is returned.
+const (
+	ExitCodeStartFailed = 1 // app failed to construct or start
+	ExitCodeStopFailed  = 2 // app failed to stop cleanly, e.g., the stop timeout expired
+	ExitCodeForced      = 3 // a second shutdown signal was received while the app was stopping
+)
+
+// RunConfig configures Run
+type RunConfig struct {
+	// StartTimeout is the maximum time allowed for the app to start. If zero, then fx.DefaultTimeout is used.
+	StartTimeout time.Duration
+	// StopTimeout is the maximum time allowed for the app to stop. If zero, then fx.DefaultTimeout is used.
+	StopTimeout time.Duration
+	// Exit is used to force the process to exit when a second shutdown signal is received. If nil, then os.Exit is used.
+	Exit func(code int)
+}
+
+func (c RunConfig) startTimeout() time.Duration {
+	if c.StartTimeout > 0 {
+		return c.StartTimeout
+	}
+	return fx.DefaultTimeout
+}
+
+func (c RunConfig) stopTimeout() time.Duration {
+	if c.StopTimeout > 0 {
+		return c.StopTimeout
+	}
+	return fx.DefaultTimeout
+}
+
+func (c RunConfig) exit(code int) {
+	if c.Exit != nil {
+		c.Exit(code)
+		return
+	}
+	os.Exit(code)
+}
+
+// Run constructs the app via New, runs it until it is shut down, and returns the process exit code.
+//
+//	func main() {
+//		os.Exit(fxapp.Run(fxapp.RunConfig{}, options...))
+//	}
+//
+// The app is run as follows:
+//   - the app is started within the start timeout. If it fails to start, then ExitCodeStartFailed is returned.
+//   - the app runs until it receives SIGINT or SIGTERM, or until it is shut down via fx.Shutdowner. If the app is
+//     shut down while it is starting, then it is stopped as soon as it has started.
+//   - the app is stopped within the stop timeout. If it fails to stop, then ExitCodeStopFailed is returned.
+//     If a second SIGINT or SIGTERM is received while the app is stopping, then the process is forced to exit with
+//     ExitCodeForced.
+//   - if the app was shut down via fx.Shutdowner, then the specified exit code is returned. Otherwise, 0 is returned.
+//
+// Each phase is logged via the app's logger.
+func Run(config RunConfig, options ...fx.Option) int {
+	var logger *zap.Logger
+	app := New(fx.Options(options...), fx.Populate(&logger))
+	if err := app.Err(); err != nil {
+		// the error is logged by fx
+		return ExitCodeStartFailed
+	}
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	stopping := make(chan struct{})
+	defer close(stopping)
+	go forceExitOnSecondSignal(config, logger, signals, stopping)
+
+	// fx only listens for signals once Wait is called. It is called before the app is started, in order to stop the
+	// app once it has started when a signal is received while it is starting.
+	shutdowns := app.Wait()
+	logger.Info("app starting", zap.Duration("timeout", config.startTimeout()))
+	startCtx, cancelStart := context.WithTimeout(context.Background(), config.startTimeout())
+	defer cancelStart()
+	if err := app.Start(startCtx); err != nil {
+		logger.Error("app failed to start", zap.Error(err))
+		return ExitCodeStartFailed
+	}
+	logger.Info("app started")
+
+	shutdown := <-shutdowns
+	logger.Info("app stopping",
+		zap.Stringer("signal", shutdown.Signal),
+		zap.Int("exitCode", shutdown.ExitCode),
+		zap.Duration("timeout", config.stopTimeout()),
+	)
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), config.stopTimeout())
+	defer cancelStop()
+	if err := app.Stop(stopCtx); err != nil {
+		logger.Error("app failed to stop", zap.Error(err))
+		return ExitCodeStopFailed
+	}
+	logger.Info("app stopped", zap.Int("exitCode", shutdown.ExitCode))
+	return shutdown.ExitCode
+}
+
+// forceExitOnSecondSignal forces the process to exit when 2 shutdown signals have been received.
+// The first signal is handled by fx, which triggers the app to stop.
+func forceExitOnSecondSignal(config RunConfig, logger *zap.Logger, signals <-chan os.Signal, done <-chan struct{}) {
+	received := 0
+	for {
+		select {
+		case <-done:
+			return
+		case sig := <-signals:
+			received++
+			if received < 2 {
+				continue
+			}
+			logger.Warn("forcing exit", zap.Stringer("signal", sig))
+			_ = logger.Sync()
+			config.exit(ExitCodeForced)
+			return
+		}
+	}
+}
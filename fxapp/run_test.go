@@ -0,0 +1,185 @@
+package fxapp_test
+
+import (
+	"context"
+	"errors"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp"
+	"go.uber.org/fx"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func onStart(fn func(ctx context.Context) error) fx.Option {
+	return fx.Invoke(func(lc fx.Lifecycle) {
+		lc.Append(fx.Hook{OnStart: fn})
+	})
+}
+
+func onStop(fn func(ctx context.Context) error) fx.Option {
+	return fx.Invoke(func(lc fx.Lifecycle) {
+		lc.Append(fx.Hook{OnStop: fn})
+	})
+}
+
+func sendSignal(t *testing.T, sig syscall.Signal) {
+	if err := syscall.Kill(syscall.Getpid(), sig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	testConfig := fxapp.RunConfig{
+		StartTimeout: 50 * time.Millisecond,
+		StopTimeout:  50 * time.Millisecond,
+	}
+
+	t.Run("shutdown exit code", func(t *testing.T) {
+		code := fxapp.Run(testConfig,
+			fx.Provide(newAppLogger),
+			fx.Invoke(func(lc fx.Lifecycle, shutdowner fx.Shutdowner) {
+				lc.Append(fx.Hook{
+					OnStart: func(ctx context.Context) error {
+						return shutdowner.Shutdown(fx.ExitCode(5))
+					},
+				})
+			}),
+		)
+		if code != 5 {
+			t.Errorf("exit code should be 5, but was %v", code)
+		}
+	})
+
+	t.Run("shutdown on signal", func(t *testing.T) {
+		stopped := false
+		code := fxapp.Run(testConfig,
+			fx.Provide(newAppLogger),
+			onStart(func(ctx context.Context) error {
+				sendSignal(t, syscall.SIGTERM)
+				return nil
+			}),
+			onStop(func(ctx context.Context) error {
+				stopped = true
+				return nil
+			}),
+		)
+		if code != 0 {
+			t.Errorf("exit code should be 0, but was %v", code)
+		}
+		if !stopped {
+			t.Error("app should have been stopped")
+		}
+	})
+
+	t.Run("signal while starting", func(t *testing.T) {
+		stopped := false
+		exitCode := make(chan int, 1)
+		go func() {
+			exitCode <- fxapp.Run(testConfig,
+				fx.Provide(newAppLogger),
+				onStart(func(ctx context.Context) error {
+					sendSignal(t, syscall.SIGTERM)
+					// the signal is received while the app is still starting
+					time.Sleep(20 * time.Millisecond)
+					return nil
+				}),
+				onStop(func(ctx context.Context) error {
+					stopped = true
+					return nil
+				}),
+			)
+		}()
+		select {
+		case code := <-exitCode:
+			if code != 0 {
+				t.Errorf("exit code should be 0, but was %v", code)
+			}
+			if !stopped {
+				t.Error("app should have been stopped")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("app should have been stopped once it started")
+		}
+	})
+
+	t.Run("second signal forces exit", func(t *testing.T) {
+		exitCode := make(chan int, 1)
+		config := testConfig
+		config.StopTimeout = time.Second
+		config.Exit = func(code int) {
+			exitCode <- code
+		}
+		fxapp.Run(config,
+			fx.Provide(newAppLogger),
+			onStart(func(ctx context.Context) error {
+				sendSignal(t, syscall.SIGINT)
+				return nil
+			}),
+			onStop(func(ctx context.Context) error {
+				sendSignal(t, syscall.SIGINT)
+				select {
+				case code := <-exitCode:
+					exitCode <- code
+				case <-ctx.Done():
+				}
+				return nil
+			}),
+		)
+		select {
+		case code := <-exitCode:
+			if code != fxapp.ExitCodeForced {
+				t.Errorf("exit code should be %v, but was %v", fxapp.ExitCodeForced, code)
+			}
+		default:
+			t.Error("exit should have been forced")
+		}
+	})
+
+	t.Run("construction failure", func(t *testing.T) {
+		if code := fxapp.Run(testConfig); code != fxapp.ExitCodeStartFailed {
+			t.Errorf("exit code should be %v, but was %v", fxapp.ExitCodeStartFailed, code)
+		}
+	})
+
+	t.Run("start failure", func(t *testing.T) {
+		code := fxapp.Run(testConfig,
+			fx.Provide(newAppLogger),
+			onStart(func(ctx context.Context) error {
+				return errors.New("BOOM!")
+			}),
+		)
+		if code != fxapp.ExitCodeStartFailed {
+			t.Errorf("exit code should be %v, but was %v", fxapp.ExitCodeStartFailed, code)
+		}
+	})
+
+	t.Run("start timeout", func(t *testing.T) {
+		code := fxapp.Run(testConfig,
+			fx.Provide(newAppLogger),
+			onStart(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+		)
+		if code != fxapp.ExitCodeStartFailed {
+			t.Errorf("exit code should be %v, but was %v", fxapp.ExitCodeStartFailed, code)
+		}
+	})
+
+	t.Run("stop timeout", func(t *testing.T) {
+		code := fxapp.Run(testConfig,
+			fx.Provide(newAppLogger),
+			onStart(func(ctx context.Context) error {
+				sendSignal(t, syscall.SIGTERM)
+				return nil
+			}),
+			onStop(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+		)
+		if code != fxapp.ExitCodeStopFailed {
+			t.Errorf("exit code should be %v, but was %v", fxapp.ExitCodeStopFailed, code)
+		}
+	})
+}
@@ -0,0 +1,189 @@
+package fxapp
+
+import (
+	"cmp"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Timing records how long a constructor or lifecycle hook took to run
+type Timing struct {
+	Name string `json:"name"`
+	// Kind is the Fx option the function was passed via, i.e., provide, decorate, supply or replace, or the hook
+	// method, i.e., OnStart or OnStop
+	Kind string `json:"kind"`
+	// Module is the name of the Fx module the constructor belongs to
+	Module string `json:"module,omitempty"`
+	// Caller is the function that appended the hook
+	Caller   string        `json:"caller,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+func (t Timing) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", t.Name)
+	enc.AddString("kind", t.Kind)
+	if t.Module != "" {
+		enc.AddString("module", t.Module)
+	}
+	if t.Caller != "" {
+		enc.AddString("caller", t.Caller)
+	}
+	enc.AddDuration("duration", t.Duration)
+	if t.Error != "" {
+		enc.AddString("error", t.Error)
+	}
+	return nil
+}
+
+type timingsLog []Timing
+
+func (t timingsLog) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, timing := range t {
+		if err := enc.AppendObject(timing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// total returns the total duration
+func (t timingsLog) total() time.Duration {
+	var total time.Duration
+	for _, timing := range t {
+		total += timing.Duration
+	}
+	return total
+}
+
+// slowestFirst returns a copy of the timings sorted by duration in descending order
+func (t timingsLog) slowestFirst() timingsLog {
+	sorted := slices.Clone(t)
+	slices.SortStableFunc(sorted, func(a, b Timing) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+	return sorted
+}
+
+// Timings records how long the app's constructors and lifecycle hooks took to run.
+//
+// Hook timings are for the most recent start and stop.
+//
+// It is safe for concurrent use.
+type Timings struct {
+	lock         sync.RWMutex
+	constructors []Timing
+	onStart      []Timing
+	onStop       []Timing
+	starting     bool
+	stopping     bool
+}
+
+// Constructors returns the constructor timings, in the order the constructors were run
+func (t *Timings) Constructors() []Timing {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return slices.Clone(t.constructors)
+}
+
+// OnStart returns the OnStart hook timings, in the order the hooks were run
+func (t *Timings) OnStart() []Timing {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return slices.Clone(t.onStart)
+}
+
+// OnStop returns the OnStop hook timings, in the order the hooks were run
+func (t *Timings) OnStop() []Timing {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return slices.Clone(t.onStop)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// record records the timings carried by the event
+func (t *Timings) record(event fxevent.Event) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	switch e := event.(type) {
+	case *fxevent.Run:
+		t.constructors = append(t.constructors, Timing{
+			Name:     e.Name,
+			Kind:     e.Kind,
+			Module:   e.ModuleName,
+			Duration: e.Runtime,
+			Error:    errorString(e.Err),
+		})
+	case *fxevent.OnStartExecuting:
+		if !t.starting {
+			t.starting = true
+			t.onStart = nil
+		}
+	case *fxevent.OnStartExecuted:
+		t.onStart = append(t.onStart, Timing{
+			Name:     e.FunctionName,
+			Kind:     "OnStart",
+			Caller:   e.CallerName,
+			Duration: e.Runtime,
+			Error:    errorString(e.Err),
+		})
+	case *fxevent.Started:
+		t.starting = false
+	case *fxevent.OnStopExecuting:
+		if !t.stopping {
+			t.stopping = true
+			t.onStop = nil
+		}
+	case *fxevent.OnStopExecuted:
+		t.onStop = append(t.onStop, Timing{
+			Name:     e.FunctionName,
+			Kind:     "OnStop",
+			Caller:   e.CallerName,
+			Duration: e.Runtime,
+			Error:    errorString(e.Err),
+		})
+	case *fxevent.Stopped:
+		t.stopping = false
+	}
+}
+
+// timingLogger records timings from the Fx events, and logs startup and shutdown reports
+type timingLogger struct {
+	fxevent.Logger
+	log     *zap.Logger
+	timings *Timings
+}
+
+func (l *timingLogger) LogEvent(event fxevent.Event) {
+	l.Logger.LogEvent(event)
+	l.timings.record(event)
+	switch e := event.(type) {
+	case *fxevent.Started:
+		constructors := timingsLog(l.timings.Constructors())
+		hooks := timingsLog(l.timings.OnStart())
+		l.log.Info("startup report",
+			zap.Bool("started", e.Err == nil),
+			zap.Duration("constructorsRuntime", constructors.total()),
+			zap.Duration("onStartRuntime", hooks.total()),
+			zap.Array("constructors", constructors.slowestFirst()),
+			zap.Array("onStart", hooks.slowestFirst()),
+		)
+	case *fxevent.Stopped:
+		hooks := timingsLog(l.timings.OnStop())
+		l.log.Info("shutdown report",
+			zap.Bool("stopped", e.Err == nil),
+			zap.Duration("onStopRuntime", hooks.total()),
+			zap.Array("onStop", hooks.slowestFirst()),
+		)
+	}
+}
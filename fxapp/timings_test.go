@@ -0,0 +1,83 @@
+package fxapp_test
+
+import (
+	"context"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimings(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	var timings *fxapp.Timings
+	app := fxapp.New(
+		fx.Supply(zap.New(core)),
+		fx.Provide(func() greeter {
+			time.Sleep(10 * time.Millisecond)
+			return greeter{}
+		}),
+		fx.Invoke(func(lc fx.Lifecycle, _ greeter) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					time.Sleep(5 * time.Millisecond)
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					time.Sleep(5 * time.Millisecond)
+					return nil
+				},
+			})
+		}),
+		fx.Populate(&timings),
+	)
+	startApp(t, app)
+	stopApp(t, app)
+
+	var slowest fxapp.Timing
+	for _, timing := range timings.Constructors() {
+		if timing.Duration > slowest.Duration {
+			slowest = timing
+		}
+	}
+	if slowest.Duration < 10*time.Millisecond || slowest.Kind != "provide" {
+		t.Errorf("constructor timing was not recorded: %+v", timings.Constructors())
+	}
+	for _, hooks := range [][]fxapp.Timing{timings.OnStart(), timings.OnStop()} {
+		if !slices.ContainsFunc(hooks, func(timing fxapp.Timing) bool {
+			return timing.Duration >= 5*time.Millisecond && timing.Caller != ""
+		}) {
+			t.Errorf("hook timing was not recorded: %+v", hooks)
+		}
+	}
+
+	startup := logs.FilterMessage("startup report").All()
+	if len(startup) != 1 {
+		t.Fatalf("startup report should be logged once: %v", len(startup))
+	}
+	fields := startup[0].ContextMap()
+	t.Log(fields)
+	constructors := fields["constructors"].([]any)
+	if constructors[0].(map[string]any)["name"] != slowest.Name {
+		t.Errorf("constructors should be sorted slowest first: %v", constructors)
+	}
+	if fields["started"] != true {
+		t.Errorf("startup report does not match: %v", fields)
+	}
+	if logs.FilterMessage("shutdown report").Len() != 1 {
+		t.Error("shutdown report should be logged")
+	}
+
+	t.Run("restart", func(t *testing.T) {
+		onStart := len(timings.OnStart())
+		startApp(t, app)
+		stopApp(t, app)
+		if len(timings.OnStart()) != onStart {
+			t.Errorf("hook timings should be for the most recent start: %v", timings.OnStart())
+		}
+	})
+}
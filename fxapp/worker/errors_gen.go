@@ -0,0 +1,98 @@
+// Code generated by errgen from errors.yaml. DO NOT EDIT.
+
+package worker
+
+import (
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"log/slog"
+)
+
+var (
+	ErrInvalidWorker       = ulid.MustParse("01M54138G2043XWM44D3F984G3")
+	ErrDuplicateWorkerName = ulid.MustParse("01M54138G2043XWM44D4XCW0MQ")
+	ErrWorkerPanic         = ulid.MustParse("01M54138G2043XWM44D64YD17W")
+	ErrWorkersNotDrained   = ulid.MustParse("01M54138G2043XWM44D6YPFEK8")
+)
+
+var errorDefinitions = []core.ErrorDefinition{
+	{
+		ID:          ErrInvalidWorker,
+		Name:        "ErrInvalidWorker",
+		Description: "worker is missing its name or run func",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/fxapp/worker",
+		Category:    core.InvalidArgument,
+	},
+	{
+		ID:          ErrDuplicateWorkerName,
+		Name:        "ErrDuplicateWorkerName",
+		Description: "worker name is already registered",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/fxapp/worker",
+		Category:    core.AlreadyExists,
+	},
+	{
+		ID:          ErrWorkerPanic,
+		Name:        "ErrWorkerPanic",
+		Description: "worker panicked",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/fxapp/worker",
+		Category:    core.Internal,
+	},
+	{
+		ID:          ErrWorkersNotDrained,
+		Name:        "ErrWorkersNotDrained",
+		Description: "workers did not stop before the stop deadline",
+		Package:     "github.com/oysterpack/oysterpack-smart-go/fxapp/worker",
+		Category:    core.DeadlineExceeded,
+	},
+}
+
+func init() {
+	core.RegisterErrors(errorDefinitions...)
+}
+
+func errInvalidWorker(worker string, cause error) core.Error {
+	return core.Error{
+		ID:       ErrInvalidWorker,
+		Name:     "ErrInvalidWorker",
+		Err:      fmt.Errorf("invalid worker: %v", worker),
+		Cause:    cause,
+		Category: core.InvalidArgument,
+	}.With(
+		slog.String("worker", worker),
+	)
+}
+
+func errDuplicateWorkerName(worker string) core.Error {
+	return core.Error{
+		ID:       ErrDuplicateWorkerName,
+		Name:     "ErrDuplicateWorkerName",
+		Err:      fmt.Errorf("worker name is already registered: %v", worker),
+		Category: core.AlreadyExists,
+	}.With(
+		slog.String("worker", worker),
+	)
+}
+
+func errWorkerPanic(worker string, recovered any) core.Error {
+	return core.Error{
+		ID:       ErrWorkerPanic,
+		Name:     "ErrWorkerPanic",
+		Err:      fmt.Errorf("worker panicked: %v: %v", worker, recovered),
+		Category: core.Internal,
+	}.With(
+		slog.String("worker", worker),
+	)
+}
+
+func errWorkersNotDrained(workers string, cause error) core.Error {
+	return core.Error{
+		ID:       ErrWorkersNotDrained,
+		Name:     "ErrWorkersNotDrained",
+		Err:      fmt.Errorf("workers did not stop: %v", workers),
+		Cause:    cause,
+		Category: core.DeadlineExceeded,
+	}.With(
+		slog.String("workers", workers),
+	)
+}
@@ -0,0 +1,10 @@
+package worker
+
+import (
+	"github.com/oysterpack/oysterpack-smart-go/core/coretest"
+	"testing"
+)
+
+func TestErrorDefinitions(t *testing.T) {
+	coretest.CheckErrorDefinitions(t, errorDefinitions...)
+}
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// workersGroup is the value group that workers are provided to
+const workersGroup = `group:"workers"`
+
+// Module provides the *Supervisor, which runs all workers provided via Provide or Supply.
+//
+// The workers are started when the app starts. When the app stops, the workers' context is cancelled, and app
+// shutdown waits for the workers to return within the stop timeout.
+var Module = fx.Module("worker",
+	fx.Provide(newSupervisor),
+	fx.Invoke(func(*Supervisor) {}),
+)
+
+// Provide registers worker constructors, i.e., functions that return a Worker
+func Provide(constructors ...any) fx.Option {
+	options := make([]fx.Option, len(constructors))
+	for i, constructor := range constructors {
+		options[i] = fx.Provide(fx.Annotate(constructor, fx.ResultTags(workersGroup)))
+	}
+	return fx.Options(options...)
+}
+
+// Supply registers workers
+func Supply(workers ...Worker) fx.Option {
+	options := make([]fx.Option, len(workers))
+	for i, worker := range workers {
+		options[i] = fx.Supply(fx.Annotate(worker, fx.ResultTags(workersGroup)))
+	}
+	return fx.Options(options...)
+}
+
+type supervisorParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Logger    *zap.Logger
+	Workers   []Worker `group:"workers"`
+}
+
+func newSupervisor(params supervisorParams) (*Supervisor, error) {
+	supervisor, err := NewSupervisor(params.Logger, params.Workers...)
+	if err != nil {
+		return nil, err
+	}
+	params.Lifecycle.Append(fx.StartStopHook(supervisor.Start, supervisor.Stop))
+	return supervisor, nil
+}
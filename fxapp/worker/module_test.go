@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"testing"
+	"time"
+)
+
+func TestModule(t *testing.T) {
+	t.Run("app stop waits for the workers to stop", func(t *testing.T) {
+		stopped := make(chan string, 2)
+		newWorker := func(name string) Worker {
+			return Worker{
+				Name: name,
+				Run: func(ctx context.Context) error {
+					<-ctx.Done()
+					stopped <- name
+					return nil
+				},
+			}
+		}
+
+		var supervisor *Supervisor
+		app := fxtest.New(t,
+			fx.Supply(zaptest.NewLogger(t)),
+			Module,
+			Supply(newWorker("poller")),
+			Provide(func() Worker {
+				return newWorker("follower")
+			}),
+			fx.Populate(&supervisor),
+		)
+		app.RequireStart()
+		waitForState(t, supervisor, "poller", Running)
+		waitForState(t, supervisor, "follower", Running)
+
+		app.RequireStop()
+		if len(stopped) != 2 {
+			t.Errorf("app stop should wait for the workers to stop: %v", len(stopped))
+		}
+		for _, status := range supervisor.Status() {
+			if status.State != Stopped {
+				t.Errorf("worker should be Stopped: %+v", status)
+			}
+		}
+	})
+
+	t.Run("app stop is bounded by the stop timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		var supervisor *Supervisor
+		app := fx.New(
+			fx.NopLogger,
+			// the worker logs after the test completes
+			fx.Supply(zap.NewNop()),
+			Module,
+			Supply(Worker{
+				Name: "stubborn",
+				Run: func(ctx context.Context) error {
+					<-release
+					return nil
+				},
+			}),
+			fx.Populate(&supervisor),
+		)
+		if err := app.Start(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		waitForState(t, supervisor, "stubborn", Running)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := app.Stop(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("app stop should fail when the workers do not stop in time: %v", err)
+		}
+	})
+
+	t.Run("workers with duplicate names fail app construction", func(t *testing.T) {
+		worker := Worker{
+			Name: "poller",
+			Run: func(ctx context.Context) error {
+				return nil
+			},
+		}
+		app := fx.New(
+			fx.NopLogger,
+			fx.Supply(zap.NewNop()),
+			Module,
+			Supply(worker, worker),
+		)
+		if err := app.Err(); !errors.Is(err, core.Error{ID: ErrDuplicateWorkerName}) {
+			t.Errorf("app construction should fail: %v", err)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Supervisor runs workers in the background and restarts them when they fail.
+//
+// It is safe for concurrent use.
+type Supervisor struct {
+	logger  *zap.Logger
+	workers []Worker
+
+	lock     sync.RWMutex
+	statuses map[string]*Status
+	cancel   context.CancelFunc
+	done     sync.WaitGroup
+}
+
+// NewSupervisor constructs a new Supervisor for the workers.
+//
+// Errors:
+//   - ErrInvalidWorker
+//   - ErrDuplicateWorkerName
+func NewSupervisor(logger *zap.Logger, workers ...Worker) (*Supervisor, error) {
+	var errs []error
+	statuses := make(map[string]*Status, len(workers))
+	for _, worker := range workers {
+		if err := worker.validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, exists := statuses[worker.Name]; exists {
+			errs = append(errs, errDuplicateWorkerName(worker.Name))
+			continue
+		}
+		statuses[worker.Name] = &Status{Name: worker.Name, State: Pending, StateTime: time.Now()}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &Supervisor{
+		logger:   logger,
+		workers:  slices.Clone(workers),
+		statuses: statuses,
+	}, nil
+}
+
+// Start starts running the workers in the background.
+//
+// Starting a Supervisor that is already started is a no-op.
+func (s *Supervisor) Start() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, worker := range s.workers {
+		s.statuses[worker.Name].Restarts = 0
+		s.done.Add(1)
+		go s.supervise(ctx, worker)
+	}
+}
+
+// Stop cancels the workers' context, and waits for the workers to return.
+//
+// If the context is done before the workers have returned, then ErrWorkersNotDrained is returned, with the
+// context error as its cause.
+func (s *Supervisor) Stop(ctx context.Context) error {
+	s.lock.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.lock.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	drained := make(chan struct{})
+	go func() {
+		s.done.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		var running []string
+		for _, status := range s.Status() {
+			if status.State == Running || status.State == Restarting {
+				running = append(running, status.Name)
+			}
+		}
+		return errWorkersNotDrained(strings.Join(running, ","), ctx.Err())
+	}
+}
+
+// Status returns the status of each worker, in the order the workers were specified
+func (s *Supervisor) Status() []Status {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	statuses := make([]Status, len(s.workers))
+	for i, worker := range s.workers {
+		statuses[i] = *s.statuses[worker.Name]
+	}
+	return statuses
+}
+
+// Lookup returns the status of the named worker
+func (s *Supervisor) Lookup(name string) (Status, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if status, ok := s.statuses[name]; ok {
+		return *status, true
+	}
+	return Status{}, false
+}
+
+func (s *Supervisor) update(name string, fn func(status *Status)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fn(s.statuses[name])
+}
+
+func (s *Supervisor) setState(name string, state State) {
+	s.update(name, func(status *Status) {
+		status.State = state
+		status.StateTime = time.Now()
+	})
+}
+
+func (s *Supervisor) supervise(ctx context.Context, worker Worker) {
+	defer s.done.Done()
+	logger := s.logger.With(zap.String("worker", worker.Name))
+	policy := worker.restartPolicy()
+	for run := 1; ; run++ {
+		s.setState(worker.Name, Running)
+		err := worker.run(ctx)
+		if ctx.Err() != nil {
+			s.setState(worker.Name, Stopped)
+			logger.Info("worker stopped")
+			return
+		}
+		if err == nil {
+			s.setState(worker.Name, Completed)
+			logger.Info("worker completed")
+			return
+		}
+
+		s.update(worker.Name, func(status *Status) {
+			status.LastError = err
+			status.LastErrorTime = time.Now()
+		})
+		if !policy.restart(run, err) {
+			s.setState(worker.Name, Failed)
+			logger.Error("worker failed", zap.Error(err), zap.Int("runs", run))
+			return
+		}
+
+		backoff := policy.Backoff(run)
+		s.setState(worker.Name, Restarting)
+		logger.Warn("worker failed - restarting", zap.Error(err), zap.Duration("backoff", backoff))
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.setState(worker.Name, Stopped)
+			logger.Info("worker stopped")
+			return
+		case <-timer.C:
+		}
+		s.update(worker.Name, func(status *Status) {
+			status.Restarts++
+		})
+	}
+}
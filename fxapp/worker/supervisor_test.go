@@ -0,0 +1,268 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errBoom = errors.New("BOOM!")
+
+func fastRestarts(maxRuns int) *RestartPolicy {
+	return &RestartPolicy{
+		MaxRuns:         maxRuns,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		Multiplier:      2,
+	}
+}
+
+// waitForState waits for the worker to transition into the specified state
+func waitForState(t *testing.T, supervisor *Supervisor, name string, state State) Status {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		status, ok := supervisor.Lookup(name)
+		if !ok {
+			t.Fatalf("worker is not registered: %v", name)
+		}
+		if status.State == state {
+			return status
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("worker %v state should be %v, but was %v", name, state, status.State)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitForRuns waits for the worker to have run at least the specified number of times
+func waitForRuns(t *testing.T, runs *atomic.Int32, count int32) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < count {
+		if time.Now().After(deadline) {
+			t.Fatalf("worker should have run %v times, but ran %v times", count, runs.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func untilStopped(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func newSupervisorForTest(t *testing.T, workers ...Worker) *Supervisor {
+	supervisor, err := NewSupervisor(zaptest.NewLogger(t), workers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return supervisor
+}
+
+func TestSupervisor(t *testing.T) {
+	t.Run("restart with backoff after error", func(t *testing.T) {
+		var runs atomic.Int32
+		supervisor := newSupervisorForTest(t, Worker{
+			Name: "poller",
+			Run: func(ctx context.Context) error {
+				if runs.Add(1) <= 3 {
+					return errBoom
+				}
+				return untilStopped(ctx)
+			},
+			Restart: fastRestarts(0),
+		})
+		if status, _ := supervisor.Lookup("poller"); status.State != Pending {
+			t.Errorf("worker should be Pending before the supervisor is started: %v", status.State)
+		}
+		supervisor.Start()
+		waitForState(t, supervisor, "poller", Running)
+		waitForRuns(t, &runs, 4)
+		status := waitForState(t, supervisor, "poller", Running)
+		if status.Restarts != 3 {
+			t.Errorf("worker should have been restarted 3 times, but was %v", status.Restarts)
+		}
+		if !errors.Is(status.LastError, errBoom) || status.LastErrorTime.IsZero() {
+			t.Errorf("last error does not match: %v", status.LastError)
+		}
+
+		if err := supervisor.Stop(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if status, _ := supervisor.Lookup("poller"); status.State != Stopped {
+			t.Errorf("worker should be Stopped: %v", status.State)
+		}
+	})
+
+	t.Run("restart after panic", func(t *testing.T) {
+		var runs atomic.Int32
+		supervisor := newSupervisorForTest(t, Worker{
+			Name: "follower",
+			Run: func(ctx context.Context) error {
+				if runs.Add(1) == 1 {
+					panic("BOOM!")
+				}
+				return untilStopped(ctx)
+			},
+			Restart: fastRestarts(0),
+		})
+		supervisor.Start()
+		defer supervisor.Stop(context.Background())
+		waitForRuns(t, &runs, 2)
+		status := waitForState(t, supervisor, "follower", Running)
+		if !errors.Is(status.LastError, core.Error{ID: ErrWorkerPanic}) || status.Restarts != 1 {
+			t.Errorf("worker should have been restarted after panicking: %+v", status)
+		}
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		var runs atomic.Int32
+		supervisor := newSupervisorForTest(t, Worker{
+			Name: "poller",
+			Run: func(ctx context.Context) error {
+				runs.Add(1)
+				return errBoom
+			},
+			Restart: fastRestarts(3),
+		})
+		supervisor.Start()
+		defer supervisor.Stop(context.Background())
+		status := waitForState(t, supervisor, "poller", Failed)
+		if runs.Load() != 3 || status.Restarts != 2 {
+			t.Errorf("worker should have run 3 times: runs = %v, restarts = %v", runs.Load(), status.Restarts)
+		}
+	})
+
+	t.Run("errors that are not retryable", func(t *testing.T) {
+		policy := fastRestarts(0)
+		policy.Restartable = core.IsRetryable
+		supervisor := newSupervisorForTest(t, Worker{
+			Name: "poller",
+			Run: func(ctx context.Context) error {
+				return errBoom
+			},
+			Restart: policy,
+		})
+		supervisor.Start()
+		defer supervisor.Stop(context.Background())
+		if status := waitForState(t, supervisor, "poller", Failed); status.Restarts != 0 {
+			t.Errorf("worker should not have been restarted: %v", status.Restarts)
+		}
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		supervisor := newSupervisorForTest(t, Worker{
+			Name: "migrator",
+			Run: func(ctx context.Context) error {
+				return nil
+			},
+		})
+		supervisor.Start()
+		defer supervisor.Stop(context.Background())
+		waitForState(t, supervisor, "migrator", Completed)
+	})
+
+	t.Run("stop while restarting", func(t *testing.T) {
+		supervisor := newSupervisorForTest(t, Worker{
+			Name: "poller",
+			Run: func(ctx context.Context) error {
+				return errBoom
+			},
+			Restart: &RestartPolicy{InitialInterval: time.Hour},
+		})
+		supervisor.Start()
+		waitForState(t, supervisor, "poller", Restarting)
+		if err := supervisor.Stop(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if status, _ := supervisor.Lookup("poller"); status.State != Stopped {
+			t.Errorf("worker should be Stopped: %v", status.State)
+		}
+	})
+
+	t.Run("stop waits for workers to drain", func(t *testing.T) {
+		var drained atomic.Bool
+		supervisor := newSupervisorForTest(t,
+			Worker{
+				Name: "slow",
+				Run: func(ctx context.Context) error {
+					<-ctx.Done()
+					time.Sleep(10 * time.Millisecond)
+					drained.Store(true)
+					return ctx.Err()
+				},
+			},
+			Worker{Name: "fast", Run: untilStopped},
+		)
+		supervisor.Start()
+		waitForState(t, supervisor, "slow", Running)
+		if err := supervisor.Stop(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if !drained.Load() {
+			t.Error("stop should have waited for the worker to return")
+		}
+		for _, status := range supervisor.Status() {
+			if status.State != Stopped || status.LastError != nil {
+				t.Errorf("worker should be Stopped without error: %+v", status)
+			}
+		}
+	})
+
+	t.Run("stop deadline", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		// the workers outlive the test, so they must not log to the test logger
+		supervisor, err := NewSupervisor(zap.NewNop(),
+			Worker{
+				Name: "stuck",
+				Run: func(ctx context.Context) error {
+					<-release
+					return nil
+				},
+			},
+			Worker{Name: "fast", Run: untilStopped},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		supervisor.Start()
+		waitForState(t, supervisor, "stuck", Running)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err = supervisor.Stop(ctx)
+		if !errors.Is(err, core.Error{ID: ErrWorkersNotDrained}) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if workers, _ := core.AttrValue[string](err, "workers"); workers != "stuck" {
+			t.Errorf("only the stuck worker should be reported: %v", workers)
+		}
+	})
+}
+
+func TestNewSupervisor(t *testing.T) {
+	_, err := NewSupervisor(zap.NewNop(),
+		Worker{Name: "poller", Run: untilStopped},
+		Worker{Name: "poller", Run: untilStopped},
+		Worker{Run: untilStopped},
+		Worker{Name: "follower"},
+		Worker{Name: "busy", Run: untilStopped, Restart: &RestartPolicy{}},
+	)
+	if !errors.Is(err, core.Error{ID: ErrDuplicateWorkerName}) {
+		t.Errorf("duplicate worker name should be reported: %v", err)
+	}
+	if !errors.Is(err, core.Error{ID: ErrInvalidWorker}) {
+		t.Errorf("invalid workers should be reported: %v", err)
+	}
+	if !strings.Contains(err.Error(), "restart policy initial interval must be positive") {
+		t.Errorf("restart policy without a backoff interval should be reported: %v", err)
+	}
+}
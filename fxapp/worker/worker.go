@@ -0,0 +1,156 @@
+// Package worker supervises long-running background workers, e.g., pollers and block followers.
+//
+// Workers are run by a Supervisor, which restarts them with backoff when they fail, and stops them when the
+// Supervisor is stopped. Module ties the Supervisor to the [Fx] app lifecycle.
+//
+// [Fx] = https://uber-go.github.io/fx/
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/oysterpack/oysterpack-smart-go/core/retry"
+	"time"
+)
+
+// Worker is a long-running background task
+type Worker struct {
+	// Name uniquely identifies the worker
+	Name string
+	// Run runs the worker until the context is cancelled, which signals the worker to stop.
+	//
+	// If Run returns an error or panics while the worker is not being stopped, then the worker is restarted per
+	// its restart policy. If Run returns nil, then the worker is done and is not restarted.
+	Run func(ctx context.Context) error
+	// Restart is the restart policy. If nil, then DefaultRestartPolicy is used.
+	Restart *RestartPolicy
+}
+
+// RestartPolicy controls how failed workers are restarted.
+//
+// Unlike retry.Policy, which only retries errors that are classified as retryable by default, workers are restarted on
+// any error by default, because workers are expected to keep running.
+type RestartPolicy struct {
+	// MaxRuns is the maximum number of times the worker is run, including the first run. If zero, then the worker is
+	// restarted indefinitely.
+	MaxRuns int
+	// InitialInterval is the backoff interval before the first restart. It must be positive, in order to prevent
+	// restarting a failing worker in a busy loop.
+	InitialInterval time.Duration
+	// MaxInterval caps the backoff interval
+	MaxInterval time.Duration
+	// Multiplier is applied to the backoff interval after each restart
+	Multiplier float64
+	// Jitter randomizes the backoff interval by +/- the specified fraction of the interval - see retry.Policy.Jitter
+	Jitter float64
+	// Restartable reports whether the worker should be restarted after failing with the error. If nil, then the worker
+	// is restarted on any error. core.IsRetryable can be used to only restart on errors that are classified as
+	// retryable.
+	Restartable func(err error) bool
+}
+
+// DefaultRestartPolicy restarts workers indefinitely, starting with a 1 sec backoff interval that doubles after each
+// restart up to 1 minute, with +/- 20% jitter.
+func DefaultRestartPolicy() RestartPolicy {
+	return RestartPolicy{
+		InitialInterval: time.Second,
+		MaxInterval:     time.Minute,
+		Multiplier:      2,
+		Jitter:          0.2,
+	}
+}
+
+// restart reports whether the worker should be restarted after failing with the error on the specified run
+func (p RestartPolicy) restart(run int, err error) bool {
+	if p.MaxRuns > 0 && run >= p.MaxRuns {
+		return false
+	}
+	return p.Restartable == nil || p.Restartable(err)
+}
+
+// Backoff returns the backoff interval to wait before the specified restart, where restart 1 is the first restart
+func (p RestartPolicy) Backoff(restart int) time.Duration {
+	return retry.Policy{
+		InitialInterval: p.InitialInterval,
+		MaxInterval:     p.MaxInterval,
+		Multiplier:      p.Multiplier,
+		Jitter:          p.Jitter,
+	}.Backoff(restart)
+}
+
+func (w Worker) validate() error {
+	var errs []error
+	if w.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if w.Run == nil {
+		errs = append(errs, errors.New("run func is required"))
+	}
+	if w.Restart != nil && w.Restart.InitialInterval <= 0 {
+		errs = append(errs, errors.New("restart policy initial interval must be positive"))
+	}
+	if len(errs) > 0 {
+		return errInvalidWorker(w.Name, errors.Join(errs...))
+	}
+	return nil
+}
+
+func (w Worker) restartPolicy() RestartPolicy {
+	if w.Restart != nil {
+		return *w.Restart
+	}
+	return DefaultRestartPolicy()
+}
+
+// run runs the worker, converting panics into ErrWorkerPanic errors
+func (w Worker) run(ctx context.Context) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errWorkerPanic(w.Name, recovered)
+		}
+	}()
+	return w.Run(ctx)
+}
+
+// State is the worker state
+type State int
+
+const (
+	Pending    State = iota + 1 // worker has not been started
+	Running                     // worker is running
+	Restarting                  // worker failed, and is waiting to be restarted
+	Completed                   // worker returned without error, i.e., it has no more work to do
+	Failed                      // worker failed and will not be restarted, per its restart policy
+	Stopped                     // worker was stopped by the supervisor
+)
+
+var stateNames = map[State]string{
+	Pending:    "Pending",
+	Running:    "Running",
+	Restarting: "Restarting",
+	Completed:  "Completed",
+	Failed:     "Failed",
+	Stopped:    "Stopped",
+}
+
+func (s State) String() string {
+	if name, ok := stateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Status is a snapshot of the worker's state
+type Status struct {
+	Name  string
+	State State
+	// Restarts is the number of times the worker has been restarted
+	Restarts int
+	// LastError is the error that the worker last failed with
+	LastError error
+	// LastErrorTime is when the worker last failed
+	LastErrorTime time.Time
+	// StateTime is when the worker transitioned into its current state
+	StateTime time.Time
+}
@@ -0,0 +1,196 @@
+// Package fxhealthcheck provides health checks as an [Fx] module.
+//
+// Health checks are contributed by any module via Provide or Supply, and are registered when the registry is
+// constructed.
+//
+// [Fx] = https://uber-go.github.io/fx/
+package fxhealthcheck
+
+import (
+	"context"
+	"github.com/oysterpack/oysterpack-smart-go/core/healthcheck"
+	"github.com/oysterpack/oysterpack-smart-go/core/healthcheck/healthhttp"
+	"github.com/oysterpack/oysterpack-smart-go/healthprom"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+// checksGroup is the value group that health checks are provided to
+const checksGroup = `group:"healthcheck.checks"`
+
+// HandlerName is the name of the http.Handler that serves the health endpoints
+const HandlerName = "healthcheck"
+
+// Module provides:
+//   - *healthcheck.Registry with all health checks provided via Provide or Supply registered
+//   - *healthcheck.Scheduler, which runs the registered checks in the background while the app is running. If a
+//     healthcheck.SchedulerConfig is provided, then it is used to configure the scheduler.
+//   - healthcheck.Reporter, which reports the scheduler's cached results
+//   - http.Handler named [HandlerName] that serves the /healthz, /livez, /readyz and /startupz endpoints - see healthhttp
+//
+// The module also registers a startup gate, which holds app startup until the Startup checks are Green. If the
+// Startup checks are not Green before the deadline, then app startup fails - see StartupGate.
+var Module = fx.Module("healthcheck",
+	fx.Provide(
+		newRegistry,
+		newScheduler,
+		newReporter,
+		fx.Annotate(
+			newHandler,
+			fx.ResultTags(`name:"`+HandlerName+`"`),
+		),
+	),
+	fx.Invoke(registerStartupGate),
+)
+
+// startup gate defaults
+//
+// DefaultStartupTimeout fits within fx.DefaultTimeout, which leaves time for the other OnStart hooks to run. The
+// scheduler's first run and each poll are bounded by the gate timeout, i.e., a slow check cannot hold startup past it.
+const (
+	DefaultStartupTimeout      = 10 * time.Second
+	DefaultStartupPollInterval = time.Second
+)
+
+// StartupGate configures how long app startup is held while waiting for the Startup checks to become Green.
+//
+// The gate is an OnStart hook: it holds the OnStart hooks that are appended after it, i.e., by components that are
+// constructed after the health check module. The gate starts the scheduler, whose first run of all checks counts
+// against the gate timeout. The Startup checks are then run on each poll, regardless of their schedule. Checks that
+// are still running when the gate timeout expires are abandoned.
+//
+// The gate is bounded by the app start timeout, i.e., fx.StartTimeout, which defaults to fx.DefaultTimeout. Thus, to
+// hold startup longer than the start timeout, the start timeout must be raised as well, e.g., via
+// fxapp.RunConfig.StartTimeout. Otherwise, the gate fails when the start timeout expires.
+type StartupGate struct {
+	// Timeout is the deadline for the Startup checks to become Green. If zero, then DefaultStartupTimeout is used.
+	// It should be less than the app start timeout - see StartupGate.
+	Timeout time.Duration
+	// PollInterval is how often the Startup checks are run. If zero, then DefaultStartupPollInterval is used.
+	PollInterval time.Duration
+}
+
+// Provide registers health check constructors, i.e., functions that return a healthcheck.Check
+func Provide(constructors ...any) fx.Option {
+	options := make([]fx.Option, len(constructors))
+	for i, constructor := range constructors {
+		options[i] = fx.Provide(fx.Annotate(constructor, fx.ResultTags(checksGroup)))
+	}
+	return fx.Options(options...)
+}
+
+// Supply registers health checks
+func Supply(checks ...healthcheck.Check) fx.Option {
+	options := make([]fx.Option, len(checks))
+	for i, check := range checks {
+		options[i] = fx.Supply(fx.Annotate(check, fx.ResultTags(checksGroup)))
+	}
+	return fx.Options(options...)
+}
+
+type registryParams struct {
+	fx.In
+
+	Checks []healthcheck.Check `group:"healthcheck.checks"`
+}
+
+func newRegistry(params registryParams) (*healthcheck.Registry, error) {
+	registry := healthcheck.NewRegistry()
+	if err := registry.Register(params.Checks...); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+type schedulerParams struct {
+	fx.In
+
+	Registry *healthcheck.Registry
+	Config   healthcheck.SchedulerConfig `optional:"true"`
+	Exporter *healthprom.Exporter        `optional:"true"`
+}
+
+func newScheduler(params schedulerParams) *healthcheck.Scheduler {
+	config := params.Config
+	if params.Exporter != nil {
+		observer := config.Runner.Observer
+		config.Runner.Observer = func(result healthcheck.CheckResult) {
+			if observer != nil {
+				observer(result)
+			}
+			params.Exporter.Observe(result)
+		}
+	}
+	// the scheduler is started and stopped by the startup gate
+	return healthcheck.NewScheduler(params.Registry, config)
+}
+
+func newReporter(scheduler *healthcheck.Scheduler) healthcheck.Reporter {
+	return scheduler
+}
+
+type startupGateParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Registry  *healthcheck.Registry
+	Scheduler *healthcheck.Scheduler
+	Gate      StartupGate `optional:"true"`
+	Logger    *zap.Logger
+}
+
+func registerStartupGate(params startupGateParams) {
+	timeout := params.Gate.Timeout
+	if timeout <= 0 {
+		timeout = DefaultStartupTimeout
+	}
+	interval := params.Gate.PollInterval
+	if interval <= 0 {
+		interval = DefaultStartupPollInterval
+	}
+	reporter := healthcheck.OnDemand{Registry: params.Registry, Runner: params.Scheduler.Runner()}
+	selector := healthcheck.Selector{Categories: []healthcheck.Category{healthcheck.Startup}}
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			params.Scheduler.Start(ctx)
+			report, err := healthcheck.AwaitGreen(ctx, reporter, selector, interval)
+			if err != nil {
+				// the OnStop hook is not run when the OnStart hook fails
+				params.Scheduler.Stop()
+				return err
+			}
+			params.Logger.Info("startup health checks are Green",
+				zap.Int("checks", len(report.Results)),
+			)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			params.Scheduler.Stop()
+			return nil
+		},
+	})
+}
+
+// Metrics exports health check results as Prometheus metrics - see healthprom.
+//
+// The metrics are registered with the prometheus.Registerer, which must be provided by the app.
+var Metrics = fx.Module("healthcheck.metrics",
+	fx.Provide(newExporter),
+)
+
+func newExporter(registerer prometheus.Registerer) (*healthprom.Exporter, error) {
+	exporter := healthprom.NewExporter()
+	if err := exporter.Register(registerer); err != nil {
+		return nil, err
+	}
+	return exporter, nil
+}
+
+func newHandler(reporter healthcheck.Reporter) http.Handler {
+	return healthhttp.NewServeMux(reporter)
+}
@@ -0,0 +1,245 @@
+package fxhealthcheck_test
+
+import (
+	"context"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"github.com/oysterpack/oysterpack-smart-go/core/healthcheck"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/admin"
+	"github.com/oysterpack/oysterpack-smart-go/fxapp/config"
+	"github.com/oysterpack/oysterpack-smart-go/fxhealthcheck"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCheck(name string, status healthcheck.Status) healthcheck.Check {
+	return healthcheck.Check{
+		ID:   ulid.Make(),
+		Name: name,
+		Run: func(time.Duration) healthcheck.Result {
+			return healthcheck.Result{Status: status}
+		},
+	}
+}
+
+func TestModule(t *testing.T) {
+	var handler struct {
+		fx.In
+
+		Handler http.Handler `name:"healthcheck"`
+	}
+	var registry *healthcheck.Registry
+	app := fxapp.New(
+		fx.Provide(zap.NewDevelopment),
+		fxhealthcheck.Module,
+		fxhealthcheck.Supply(newCheck("foo", healthcheck.Green)),
+		fxhealthcheck.Provide(func() healthcheck.Check {
+			return newCheck("bar", healthcheck.Yellow)
+		}),
+		fx.Populate(&handler, &registry),
+	)
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := app.Stop(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if checks := registry.List(); len(checks) != 2 {
+		t.Errorf("health checks were not registered: %v", checks)
+	}
+	w := httptest.NewRecorder()
+	handler.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	t.Log(w.Body.String())
+	if w.Code != http.StatusOK {
+		t.Errorf("unexpected status code: %v", w.Code)
+	}
+}
+
+func TestModule_InvalidCheck(t *testing.T) {
+	var registry *healthcheck.Registry
+	check := newCheck("foo", healthcheck.Green)
+	app := fxapp.New(
+		fx.Provide(zap.NewDevelopment),
+		fxhealthcheck.Module,
+		fxhealthcheck.Supply(check, check),
+		fx.Populate(&registry),
+	)
+	if err := app.Err(); err == nil {
+		t.Error("app should fail to initialize because the check is registered twice")
+	}
+}
+
+func TestModule_StartupGate(t *testing.T) {
+	startupCheck := func(ready *atomic.Bool) healthcheck.Check {
+		check := newCheck("startup", healthcheck.Green)
+		check.Categories = []healthcheck.Category{healthcheck.Startup}
+		check.Run = func(time.Duration) healthcheck.Result {
+			if ready.Load() {
+				return healthcheck.Result{Status: healthcheck.Green}
+			}
+			return healthcheck.Result{Status: healthcheck.Red}
+		}
+		return check
+	}
+	gate := fxhealthcheck.StartupGate{Timeout: 100 * time.Millisecond, PollInterval: time.Millisecond}
+
+	t.Run("startup is held until the startup checks are Green", func(t *testing.T) {
+		var ready atomic.Bool
+		time.AfterFunc(20*time.Millisecond, func() { ready.Store(true) })
+		var started bool
+		app := fxapp.New(
+			fx.Provide(zap.NewDevelopment),
+			fxhealthcheck.Module,
+			fxhealthcheck.Supply(startupCheck(&ready)),
+			fx.Supply(gate),
+			fx.Invoke(func(lc fx.Lifecycle, _ *healthcheck.Scheduler) {
+				lc.Append(fx.StartHook(func() {
+					started = ready.Load()
+				}))
+			}),
+		)
+		if err := app.Start(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = app.Stop(context.Background())
+		}()
+		if !started {
+			t.Error("app should not be started until the startup checks are Green")
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		var ready atomic.Bool
+		app := fxapp.New(
+			fx.Provide(zap.NewDevelopment),
+			fxhealthcheck.Module,
+			fxhealthcheck.Supply(startupCheck(&ready)),
+			fx.Supply(gate),
+		)
+		err := app.Start(context.Background())
+		if !errors.Is(err, core.Error{ID: healthcheck.ErrHealthChecksNotGreen}) {
+			t.Errorf("app should fail to start because the startup checks are not Green: %v", err)
+		}
+	})
+
+	t.Run("slow checks do not overrun the gate timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		check := newCheck("slow", healthcheck.Green)
+		check.Categories = []healthcheck.Category{healthcheck.Startup}
+		check.Run = func(time.Duration) healthcheck.Result {
+			<-release
+			return healthcheck.Result{Status: healthcheck.Green}
+		}
+		app := fxapp.New(
+			fx.Provide(zap.NewDevelopment),
+			fxhealthcheck.Module,
+			fxhealthcheck.Supply(check),
+			fx.Supply(gate),
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := app.Start(ctx)
+		if !errors.Is(err, core.Error{ID: healthcheck.ErrHealthChecksNotGreen}) {
+			t.Errorf("app should fail to start when the gate timeout expires: %v", err)
+		}
+	})
+
+	t.Run("gate is bounded by the start timeout", func(t *testing.T) {
+		var ready atomic.Bool
+		app := fxapp.New(
+			fx.Provide(zap.NewDevelopment),
+			fxhealthcheck.Module,
+			fxhealthcheck.Supply(startupCheck(&ready)),
+			fx.Supply(fxhealthcheck.StartupGate{Timeout: time.Hour, PollInterval: time.Millisecond}),
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := app.Start(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("app should fail to start when the start timeout expires: %v", err)
+		}
+		if time.Since(start) > time.Second {
+			t.Error("gate should not hold startup past the start timeout")
+		}
+	})
+
+	t.Run("default timeout fits within the default start timeout", func(t *testing.T) {
+		if fxhealthcheck.DefaultStartupTimeout >= fx.DefaultTimeout {
+			t.Errorf("default startup timeout (%v) should be less than fx.DefaultTimeout (%v)", fxhealthcheck.DefaultStartupTimeout, fx.DefaultTimeout)
+		}
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	app := fxapp.New(
+		fx.Provide(zap.NewDevelopment),
+		fx.Supply(fx.Annotate(registry, fx.As(new(prometheus.Registerer)))),
+		fxhealthcheck.Module,
+		fxhealthcheck.Metrics,
+		fxhealthcheck.Supply(newCheck("foo", healthcheck.Red)),
+	)
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = app.Stop(context.Background())
+	}()
+
+	expected := `
+# HELP healthcheck_failures_total Number of Red health check results
+# TYPE healthcheck_failures_total counter
+healthcheck_failures_total{check="foo",error_id=""} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "healthcheck_failures_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModule_Admin(t *testing.T) {
+	if fxhealthcheck.HandlerName != admin.HealthHandlerName {
+		t.Fatalf("admin server health handler name does not match: %v", admin.HealthHandlerName)
+	}
+	var server *admin.Server
+	app := fxapp.New(
+		fx.Provide(zap.NewDevelopment),
+		fx.Supply(config.Loader{Args: []string{"--admin.addr=127.0.0.1:0"}}),
+		fxhealthcheck.Module,
+		fxhealthcheck.Supply(newCheck("foo", healthcheck.Red)),
+		admin.Module,
+		fx.Populate(&server),
+	)
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := app.Stop(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	response, err := http.Get("http://" + server.Addr().String() + "/readyz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("admin server should serve the health endpoints: %v", response.StatusCode)
+	}
+}
@@ -0,0 +1,37 @@
+module github.com/oysterpack/oysterpack-smart-go/fxhealthcheck
+
+go 1.21.4
+
+require (
+	github.com/oklog/ulid/v2 v2.1.0
+	github.com/oysterpack/oysterpack-smart-go/core v0.0.0-unpublished
+	github.com/oysterpack/oysterpack-smart-go/fxapp v0.0.0-unpublished
+	github.com/oysterpack/oysterpack-smart-go/healthprom v0.0.0-unpublished
+	github.com/prometheus/client_golang v1.17.0
+	go.uber.org/fx v1.23.0
+	go.uber.org/zap v1.26.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.3.2 // indirect
+	github.com/benbjohnson/clock v1.3.5 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.4 // indirect
+	github.com/prometheus/client_model v0.4.1-0.20230718164431-9a2bf3000d16 // indirect
+	github.com/prometheus/common v0.44.0 // indirect
+	github.com/prometheus/procfs v0.11.1 // indirect
+	go.uber.org/dig v1.18.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	golang.org/x/sys v0.11.0 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/oysterpack/oysterpack-smart-go/core v0.0.0-unpublished => ../core
+	github.com/oysterpack/oysterpack-smart-go/fxapp v0.0.0-unpublished => ../fxapp
+	github.com/oysterpack/oysterpack-smart-go/healthprom v0.0.0-unpublished => ../healthprom
+)
@@ -0,0 +1,84 @@
+// Package healthprom exports health check results as [Prometheus] metrics:
+//   - healthcheck_status - gauge for the latest status of each check: 1 = Green, 2 = Yellow, 3 = Red
+//   - healthcheck_duration_seconds - histogram for check durations
+//   - healthcheck_failures_total - counter for Red results, labelled by check name and error ID. The error ID is the
+//     ID of the first core.Error in the result's error tree, or empty if there is none.
+//
+// The exporter is plugged in as the healthcheck.Runner observer - see Exporter.Observe.
+//
+// [Prometheus] = https://prometheus.io/
+package healthprom
+
+import (
+	"errors"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"github.com/oysterpack/oysterpack-smart-go/core/healthcheck"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metric label names
+const (
+	CheckLabel   = "check"
+	CheckIDLabel = "check_id"
+	ErrorIDLabel = "error_id"
+)
+
+// Exporter exports health check results as Prometheus metrics.
+//
+// It is safe for concurrent use.
+type Exporter struct {
+	status   *prometheus.GaugeVec
+	duration *prometheus.HistogramVec
+	failures *prometheus.CounterVec
+}
+
+// NewExporter constructs a new Exporter. The metrics must be registered before they are scraped - see Register.
+func NewExporter() *Exporter {
+	return &Exporter{
+		status: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "healthcheck_status",
+			Help: "Latest health check status: 1 = Green, 2 = Yellow, 3 = Red",
+		}, []string{CheckLabel, CheckIDLabel}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "healthcheck_duration_seconds",
+			Help:    "Health check duration in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 8), // 1 msec -> ~16 sec
+		}, []string{CheckLabel}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "healthcheck_failures_total",
+			Help: "Number of Red health check results",
+		}, []string{CheckLabel, ErrorIDLabel}),
+	}
+}
+
+// Register registers the metrics with the registerer
+func (e *Exporter) Register(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{e.status, e.duration, e.failures} {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Observe records the check result.
+//
+// Its signature matches healthcheck.Runner.Observer. Impacted results are not recorded in the duration histogram
+// because the check was not run.
+func (e *Exporter) Observe(result healthcheck.CheckResult) {
+	e.status.WithLabelValues(result.Check.Name, result.Check.ID.String()).Set(float64(result.Status))
+	if !result.Impacted {
+		e.duration.WithLabelValues(result.Check.Name).Observe(result.Duration.Seconds())
+	}
+	if result.Status == healthcheck.Red {
+		e.failures.WithLabelValues(result.Check.Name, errorID(result.Err)).Inc()
+	}
+}
+
+func errorID(err error) string {
+	var e core.Error
+	if errors.As(err, &e) {
+		return e.ID.String()
+	}
+	return ""
+}
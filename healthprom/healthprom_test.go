@@ -0,0 +1,94 @@
+package healthprom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"github.com/oysterpack/oysterpack-smart-go/core"
+	"github.com/oysterpack/oysterpack-smart-go/core/healthcheck"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"testing"
+	"time"
+)
+
+var errUnavailable = core.Error{
+	ID:   ulid.MustParse("01HH4A6V2N7Q3K5T8W1Y9B0CXD"),
+	Name: "ErrUnavailable",
+	Err:  errors.New("service unavailable"),
+}
+
+func TestExporter(t *testing.T) {
+	exporter := NewExporter()
+	registry := prometheus.NewRegistry()
+	if err := exporter.Register(registry); err != nil {
+		t.Fatal(err)
+	}
+
+	algodStatus := healthcheck.Green
+	algod := healthcheck.Check{
+		ID:   ulid.Make(),
+		Name: "algod",
+		Run: func(time.Duration) healthcheck.Result {
+			if algodStatus == healthcheck.Red {
+				return healthcheck.Result{Status: healthcheck.Red, Err: fmt.Errorf("wrapped: %w", errUnavailable)}
+			}
+			return healthcheck.Result{Status: algodStatus}
+		},
+	}
+	kmd := healthcheck.Check{
+		ID:        ulid.Make(),
+		Name:      "kmd",
+		DependsOn: []ulid.ULID{algod.ID},
+		Run: func(time.Duration) healthcheck.Result {
+			return healthcheck.Result{Status: healthcheck.Red}
+		},
+	}
+	runner := healthcheck.Runner{Observer: exporter.Observe}
+
+	runner.Run(context.Background(), algod, kmd)
+	if status := testutil.ToFloat64(exporter.status.WithLabelValues("algod", algod.ID.String())); status != 1 {
+		t.Errorf("algod status should be Green: %v", status)
+	}
+	if failures := testutil.ToFloat64(exporter.failures.WithLabelValues("kmd", "")); failures != 1 {
+		t.Errorf("kmd failure should be counted without an error ID: %v", failures)
+	}
+
+	algodStatus = healthcheck.Red
+	runner.Run(context.Background(), algod, kmd)
+	runner.Run(context.Background(), algod, kmd)
+	if status := testutil.ToFloat64(exporter.status.WithLabelValues("algod", algod.ID.String())); status != 3 {
+		t.Errorf("algod status should be Red: %v", status)
+	}
+	if failures := testutil.ToFloat64(exporter.failures.WithLabelValues("algod", errUnavailable.ID.String())); failures != 2 {
+		t.Errorf("algod failures should be labelled by error ID: %v", failures)
+	}
+	if failures := testutil.ToFloat64(exporter.failures.WithLabelValues("kmd", healthcheck.ErrHealthCheckImpacted.String())); failures != 2 {
+		t.Errorf("impacted kmd failures should be labelled by the impacted error ID: %v", failures)
+	}
+
+	// kmd was only run once - it was impacted on the last 2 runs
+	if count := testutil.CollectAndCount(exporter.duration); count != 2 {
+		t.Errorf("expected a histogram per check: %v", count)
+	}
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, metric := range metrics {
+		if metric.GetName() == "healthcheck_duration_seconds" {
+			for _, m := range metric.GetMetric() {
+				if m.GetLabel()[0].GetValue() == "kmd" && m.GetHistogram().GetSampleCount() != 1 {
+					t.Errorf("impacted results should not be recorded in the duration histogram: %v", m)
+				}
+			}
+		}
+	}
+
+	t.Run("metrics can only be registered once", func(t *testing.T) {
+		if err := exporter.Register(registry); err == nil {
+			t.Error("registering the metrics again should fail")
+		}
+	})
+}